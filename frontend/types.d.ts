@@ -17,6 +17,9 @@ interface Program {
 	coverUrl: string
 	bannerUrl: string
 	heroUrl: string
+	requires?: string[]
+	recommends?: string[]
+	conflicts?: string[]
 }
 
 interface ConsoleEmulator {
@@ -59,9 +62,47 @@ interface Synchronizable {
 	type: string
 	source: PathInfo
 	destination: PathInfo
+	include: string[]
+	exclude: string[]
 	recommended: boolean
 }
 
+interface StateChange {
+	path: string
+	action: string
+	issue: string
+	sourceSize: number
+	sourceHash: string
+	destinationSize: number
+	destinationHash: string
+}
+
+interface StateDiff {
+	platform: string
+	type: string
+	source: string
+	destination: string
+	changes: StateChange[]
+	issues: number
+}
+
+interface BIOSCheck {
+	file: string
+	path: string
+	status: string
+	required: boolean
+	size: number
+	md5: string
+	sha1: string
+}
+
+interface BIOSReport {
+	platform: string
+	path: string
+	ready: boolean
+	files: BIOSCheck[]
+}
+
 interface Shortcut {
 	id: string
 	program: string
@@ -108,6 +149,17 @@ interface SyncLibraryResult {
 	error: string
 }
 
+interface ImportDesktopData {
+	enabled: boolean
+	folders: string[]
+	entries: string[]
+}
+
+interface ImportDesktopResult {
+	status: string
+	error: string
+}
+
 interface ListProgramsResult {
 	status: string
 	error: string
@@ -191,6 +243,35 @@ interface ModifyShortcutResult {
 	error: string
 }
 
+interface ProgramUpdate {
+	program: string
+	runtime: string
+	status: string
+	current: string
+	latest: string
+}
+
+interface ListOutdatedResult {
+	status: string
+	error: string
+	data: ProgramUpdate[]
+}
+
+interface Download {
+	url: string
+	destination: string
+	downloaded: number
+	total: number
+	status: string
+	error: string
+}
+
+interface ListDownloadsResult {
+	status: string
+	error: string
+	data: Download[]
+}
+
 interface InstallProgramsData {
 	programs: string[]
 }
@@ -200,6 +281,15 @@ interface InstallProgramsResult {
 	error: string
 }
 
+interface RollbackProgramData {
+	program: string
+}
+
+interface RollbackProgramResult {
+	status: string
+	error: string
+}
+
 interface RemoveProgramsData {
 	programs: string[]
 }
@@ -209,6 +299,17 @@ interface RemoveProgramsResult {
 	error: string
 }
 
+interface ConfigureProgramsData {
+	action: string
+	programs: string[]
+	preferences: string[]
+}
+
+interface ConfigureProgramsResult {
+	status: string
+	error: string
+}
+
 interface ListStateParams extends URLSearchParams {
 	action: string
 	platform: string
@@ -221,6 +322,91 @@ interface ListStateResult {
 	data: Synchronizable[]
 }
 
+interface StateDiffParams extends URLSearchParams {
+	action: string
+	platforms: string
+	preferences: string
+}
+
+interface StateDiffResult {
+	status: string
+	error: string
+	data: StateDiff[]
+}
+
+interface CheckBIOSParams extends URLSearchParams {
+	platforms: string
+	preferences: string
+}
+
+interface CheckBIOSResult {
+	status: string
+	error: string
+	data: BIOSReport[]
+}
+
+interface ROMFile {
+	path: string
+	relativePath: string
+	platform: string
+	emulator: string
+	status: string
+}
+
+interface ROMsReport {
+	total: number
+	orphans: Shortcut[]
+	files: ROMFile[]
+}
+
+interface ReportROMsParams extends URLSearchParams {
+	platforms: string
+	preferences: string
+}
+
+interface ReportROMsResult {
+	status: string
+	error: string
+	data: ROMsReport
+}
+
+interface PruneROMsData {
+	platforms: string[]
+	preferences: string[]
+}
+
+interface PruneROMsResult {
+	status: string
+	error: string
+	data: ROMsReport
+}
+
+interface ROMOverride {
+	relativePath: string
+	emulator: string
+	arguments: string
+	environment: string[]
+}
+
+interface ListROMOverridesResult {
+	status: string
+	error: string
+	data: ROMOverride[]
+}
+
+interface ModifyROMOverrideData {
+	action: string
+	relativePath: string
+	emulator: string
+	arguments: string
+	environment: string[]
+}
+
+interface ModifyROMOverrideResult {
+	status: string
+	error: string
+}
+
 interface BackupStateData {
 	platforms: string[]
 	preferences: string[]
@@ -63,3 +63,17 @@ func Start(command *exec.Cmd) error {
 	<-finished
 	return err
 }
+
+// Capture runs script and return the standard output content
+func Capture(command *exec.Cmd) (string, error) {
+
+	var stdout bytes.Buffer
+	command.Stdout = &stdout
+
+	err := Run(command)
+	if err != nil {
+		return "", err
+	}
+
+	return stdout.String(), nil
+}
@@ -4,11 +4,15 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sync"
 )
 
 // Output
 var output io.Writer = os.Stdout
 
+// Lock for messages printed from concurrent tasks
+var outputLock sync.Mutex
+
 // Colors
 var (
 	ColorDefault = ""
@@ -29,6 +33,8 @@ var (
 
 // Output set the destination for console messages
 func Output(writer io.Writer) {
+	outputLock.Lock()
+	defer outputLock.Unlock()
 	output = writer
 }
 
@@ -39,6 +45,9 @@ func NoColor() bool {
 
 // Print a message to console output with color level
 func Printf(color string, format string, args ...any) {
+	outputLock.Lock()
+	defer outputLock.Unlock()
+
 	if NoColor() {
 		fmt.Fprintf(output, format, args...)
 	} else {
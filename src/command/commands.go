@@ -6,11 +6,15 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/desktop"
 	"github.com/mateussouzaweb/nicedeck/src/management"
 	"github.com/mateussouzaweb/nicedeck/src/platforms"
+	"github.com/mateussouzaweb/nicedeck/src/platforms/configure"
+	"github.com/mateussouzaweb/nicedeck/src/platforms/console"
 	"github.com/mateussouzaweb/nicedeck/src/platforms/state"
 	"github.com/mateussouzaweb/nicedeck/src/programs"
 	"github.com/mateussouzaweb/nicedeck/src/scraper"
@@ -173,6 +177,12 @@ func syncLibrary(_ Context) error {
 		errors.Join(err, management.SaveLibrary())
 	}()
 
+	// Show shortcuts of removable media that is present again
+	err = management.RestoreROMsMedia()
+	if err != nil {
+		return err
+	}
+
 	// Sync library
 	err = management.SyncLibrary()
 	if err != nil {
@@ -184,6 +194,54 @@ func syncLibrary(_ Context) error {
 	return nil
 }
 
+// Configure desktop entries import and sync library
+func importDesktop(context Context) error {
+
+	// Retrieve command details
+	options := &desktop.Import{
+		Enabled: !context.Flag("--disable", false),
+		Folders: context.Multiple("--folders", ","),
+		Entries: context.Multiple("--entries", ","),
+	}
+
+	// Init user library
+	err := management.InitLibrary()
+	if err != nil {
+		return err
+	}
+
+	// Load user library
+	err = management.LoadLibrary()
+	if err != nil {
+		return err
+	}
+
+	// Make sure to save library on finish
+	defer func() {
+		errors.Join(err, management.SaveLibrary())
+	}()
+
+	// Save import options
+	err = management.ConfigureDesktopImport(options)
+	if err != nil {
+		return err
+	}
+
+	// Sync library to apply import
+	err = management.SyncLibrary()
+	if err != nil {
+		return err
+	}
+
+	if options.Enabled {
+		cli.Printf(cli.ColorSuccess, "Desktop entries imported!\n")
+	} else {
+		cli.Printf(cli.ColorSuccess, "Desktop entries import disabled!\n")
+	}
+
+	return nil
+}
+
 // Launch shortcut
 func launchShortcut(context Context) error {
 
@@ -434,8 +492,9 @@ func installPrograms(context Context) error {
 	// Retrieve command details
 	include := context.Multiple("--programs", ",")
 	preferences := context.Multiple("--preferences", ",")
+	outdated := context.Flag("--outdated", false)
 
-	if len(include) == 0 {
+	if len(include) == 0 && !outdated {
 		return fmt.Errorf("programs list is required")
 	}
 
@@ -456,6 +515,33 @@ func installPrograms(context Context) error {
 		errors.Join(err, management.SaveLibrary())
 	}()
 
+	// Update only outdated programs when requested
+	// Program list, when given, limits the programs to update
+	if outdated {
+		updates, err := management.CheckProgramsUpdates()
+		if err != nil {
+			return err
+		}
+
+		list := []string{}
+		for _, update := range updates {
+			if update.Status != "outdated" {
+				continue
+			}
+			if len(include) > 0 && !slices.Contains(include, update.Program) {
+				continue
+			}
+			list = append(list, update.Program)
+		}
+
+		if len(list) == 0 {
+			cli.Printf(cli.ColorSuccess, "All programs are up to date!\n")
+			return nil
+		}
+
+		include = list
+	}
+
 	// Install programs in the list
 	options := programs.ToOptions(include, preferences)
 	err = management.InstallPrograms(options)
@@ -468,6 +554,172 @@ func installPrograms(context Context) error {
 	return nil
 }
 
+// List installed programs with updates status
+func listOutdated(_ Context) error {
+
+	// Init user library
+	err := management.InitLibrary()
+	if err != nil {
+		return err
+	}
+
+	// Check updates of installed programs
+	updates, err := management.CheckProgramsUpdates()
+	if err != nil {
+		return err
+	}
+
+	for _, update := range updates {
+		color := cli.ColorDefault
+		if update.Status == "outdated" {
+			color = cli.ColorWarn
+		}
+
+		versions := ""
+		if update.Current != "" || update.Latest != "" {
+			versions = fmt.Sprintf(" (%s -> %s)", update.Current, update.Latest)
+		}
+
+		cli.Printf(color, "%s - %s [%s]%s\n", update.Program, update.Status, update.Runtime, versions)
+	}
+
+	return nil
+}
+
+// Restore previous version of program
+func rollbackProgram(context Context) error {
+
+	// Retrieve command details
+	program := context.Arg("--program", "")
+	if program == "" {
+		return fmt.Errorf("program is required")
+	}
+
+	// Init user library
+	err := management.InitLibrary()
+	if err != nil {
+		return err
+	}
+
+	return management.RollbackProgram(program)
+}
+
+// Apply setup declared in profile file
+func applyProfile(context Context) error {
+
+	// Retrieve command details
+	path := context.Arg("--profile", "")
+	dryRun := context.Flag("--dry-run", false)
+
+	if path == "" {
+		return fmt.Errorf("profile file is required")
+	}
+
+	// Init user library
+	err := management.InitLibrary()
+	if err != nil {
+		return err
+	}
+
+	// Load user library to compare with current setup
+	err = management.LoadLibrary()
+	if err != nil {
+		return err
+	}
+
+	// Read profile and compare with current setup
+	profile, err := management.ReadProfile(path)
+	if err != nil {
+		return err
+	}
+
+	plan, err := management.PlanProfile(profile)
+	if err != nil {
+		return err
+	}
+
+	if plan.Empty() {
+		cli.Printf(cli.ColorSuccess, "Setup already matches the profile!\n")
+		return nil
+	}
+
+	// Print changes to apply
+	printChanges := func(action string, list []string) {
+		if len(list) > 0 {
+			cli.Printf(cli.ColorDefault, "%s: %s\n", action, strings.Join(list, ", "))
+		}
+	}
+
+	printChanges("Install", plan.Install)
+	printChanges("Configure", plan.Configure)
+	printChanges("Remove", plan.Remove)
+	printChanges("Update settings", plan.Settings)
+
+	// Print changes only when running in dry-run mode
+	if dryRun {
+		return nil
+	}
+
+	// Make sure to save library on finish
+	defer func() {
+		errors.Join(err, management.SaveLibrary())
+	}()
+
+	// Apply profile changes
+	err = management.ApplyProfile(profile, plan)
+	if err != nil {
+		return err
+	}
+
+	cli.Printf(cli.ColorSuccess, "Profile applied!\n")
+
+	return nil
+}
+
+// Export current setup as profile file
+func exportProfile(context Context) error {
+
+	// Retrieve command details
+	path := context.Arg("--profile", "")
+
+	// Init user library
+	err := management.InitLibrary()
+	if err != nil {
+		return err
+	}
+
+	// Load user library to read current setup
+	err = management.LoadLibrary()
+	if err != nil {
+		return err
+	}
+
+	profile, err := management.ExportProfile()
+	if err != nil {
+		return err
+	}
+
+	// Print profile when no file was given
+	if path == "" {
+		result, err := json.MarshalIndent(profile, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		cli.Printf(cli.ColorDefault, "%s\n", string(result))
+		return nil
+	}
+
+	err = management.WriteProfile(path, profile)
+	if err != nil {
+		return err
+	}
+
+	cli.Printf(cli.ColorSuccess, "Profile exported to %s\n", path)
+
+	return nil
+}
+
 // Remove programs
 func removePrograms(context Context) error {
 
@@ -508,6 +760,39 @@ func removePrograms(context Context) error {
 	return nil
 }
 
+// Configure programs
+func configurePrograms(context Context) error {
+
+	// Retrieve command details
+	include := context.Multiple("--programs", ",")
+	preferences := context.Multiple("--preferences", ",")
+	action := "apply"
+
+	if len(include) == 0 {
+		return fmt.Errorf("programs list is required")
+	}
+	if context.Flag("--revert", false) {
+		action = "revert"
+	}
+
+	// Init user library
+	err := management.InitLibrary()
+	if err != nil {
+		return err
+	}
+
+	// Apply or revert configuration of programs in the list
+	options := configure.ToOptions(action, include, preferences)
+	err = management.ConfigurePrograms(options)
+	if err != nil {
+		return err
+	}
+
+	cli.Printf(cli.ColorSuccess, "Process finished!\n")
+
+	return nil
+}
+
 // List state
 func listState(context Context) error {
 
@@ -571,6 +856,53 @@ func listState(context Context) error {
 	return nil
 }
 
+// Print state diff and return the number of detected issues
+func printStateDiff(options *state.Options) (int, error) {
+
+	// Retrieve diff results
+	result, err := management.GetStateDiff(options)
+	if err != nil {
+		return 0, err
+	}
+
+	// Print results
+	issues := 0
+	for _, item := range result {
+		cli.Printf(cli.ColorDefault, "Platform: %s (%s)\n", item.Platform, item.Type)
+		cli.Printf(cli.ColorDefault, "Source: %s\n", item.Source)
+		cli.Printf(cli.ColorDefault, "Destination: %s\n", item.Destination)
+
+		if len(item.Changes) == 0 {
+			cli.Printf(cli.ColorDefault, "No changes\n\n")
+			continue
+		}
+
+		for _, change := range item.Changes {
+			color := cli.ColorDefault
+			hash := change.SourceHash
+			size := change.SourceSize
+			if change.Action == "deleted" {
+				hash = change.DestinationHash
+				size = change.DestinationSize
+			}
+			if change.Issue != "" {
+				color = cli.ColorWarn
+			}
+
+			cli.Printf(color, "  %-8s %s (%d bytes, sha256: %s)", change.Action, change.Path, size, hash)
+			if change.Issue != "" {
+				cli.Printf(color, " [%s]", change.Issue)
+			}
+			cli.Printf(color, "\n")
+		}
+
+		cli.Printf(cli.ColorDefault, "\n")
+		issues += item.Issues
+	}
+
+	return issues, nil
+}
+
 // Backup state
 func backupState(context Context) error {
 
@@ -588,8 +920,14 @@ func backupState(context Context) error {
 		return err
 	}
 
-	// Process synchronization
+	// Print changes only when running in dry-run mode
 	options := state.ToOptions("backup", include, preferences)
+	if context.Flag("--dry-run", false) {
+		_, err = printStateDiff(options)
+		return err
+	}
+
+	// Process synchronization
 	err = management.SyncState(options)
 	if err != nil {
 		return err
@@ -615,8 +953,14 @@ func restoreState(context Context) error {
 		return err
 	}
 
-	// Process synchronization
+	// Print changes only when running in dry-run mode
 	options := state.ToOptions("restore", include, preferences)
+	if context.Flag("--dry-run", false) {
+		_, err = printStateDiff(options)
+		return err
+	}
+
+	// Process synchronization
 	err = management.SyncState(options)
 	if err != nil {
 		return err
@@ -625,6 +969,162 @@ func restoreState(context Context) error {
 	return nil
 }
 
+// Verify state
+func verifyState(context Context) error {
+
+	// Retrieve command details
+	action := context.Arg("--action", "backup")
+	include := context.Multiple("--platforms", ",")
+	preferences := context.Multiple("--preferences", ",")
+
+	if action != "backup" && action != "restore" {
+		return fmt.Errorf("invalid action: %s", action)
+	}
+	if len(include) == 0 {
+		return fmt.Errorf("platform list is required")
+	}
+
+	// Init user library
+	err := management.InitLibrary()
+	if err != nil {
+		return err
+	}
+
+	// Compare state and print results
+	options := state.ToOptions(action, include, preferences)
+	issues, err := printStateDiff(options)
+	if err != nil {
+		return err
+	}
+
+	if issues > 0 {
+		return fmt.Errorf("state verification found %d issues", issues)
+	}
+
+	cli.Printf(cli.ColorSuccess, "State verified!\n")
+	return nil
+}
+
+// Check BIOS
+func checkBIOS(context Context) error {
+
+	// Retrieve command details
+	include := context.Multiple("--platforms", ",")
+	preferences := context.Multiple("--preferences", ",")
+
+	// Init user library
+	err := management.InitLibrary()
+	if err != nil {
+		return err
+	}
+
+	// Retrieve BIOS reports
+	options := platforms.ToOptions(include, preferences)
+	result, err := management.CheckBIOS(options)
+	if err != nil {
+		return err
+	}
+
+	// Print results
+	missing := 0
+	for _, report := range result {
+		if len(report.Files) == 0 {
+			continue
+		}
+
+		status := "READY"
+		if !report.Ready {
+			status = "NOT READY"
+			missing++
+		}
+
+		cli.Printf(cli.ColorDefault, "Platform: %s (%s)\n", report.Platform, status)
+		cli.Printf(cli.ColorDefault, "Path: %s\n", report.Path)
+
+		for _, file := range report.Files {
+			color := cli.ColorDefault
+			if file.Status != "valid" && (file.Required || file.Status != "missing") {
+				color = cli.ColorWarn
+			}
+
+			requirement := "optional"
+			if file.Required {
+				requirement = "required"
+			}
+
+			cli.Printf(color, "  %-10s %s (%s)\n", file.Status, file.File, requirement)
+		}
+
+		cli.Printf(cli.ColorDefault, "\n")
+	}
+
+	if missing > 0 {
+		return fmt.Errorf("required BIOS is missing for %d platforms", missing)
+	}
+
+	cli.Printf(cli.ColorSuccess, "BIOS verified!\n")
+	return nil
+}
+
+// Report ROMs health
+func reportROMs(context Context) error {
+
+	// Retrieve command details
+	include := context.Multiple("--platforms", ",")
+	preferences := context.Multiple("--preferences", ",")
+	prune := context.Flag("--prune", false)
+
+	// Init user library
+	err := management.InitLibrary()
+	if err != nil {
+		return err
+	}
+
+	// Load user library
+	err = management.LoadLibrary()
+	if err != nil {
+		return err
+	}
+
+	// Make sure to save library on finish
+	defer func() {
+		errors.Join(err, management.SaveLibrary())
+	}()
+
+	// Generate report
+	options := platforms.ToOptions(include, preferences)
+	report, err := management.GetPlatformsReport(options)
+	if err != nil {
+		return err
+	}
+
+	// Print results
+	cli.Printf(cli.ColorDefault, "Valid ROMs: %d\n\n", report.Total)
+
+	cli.Printf(cli.ColorDefault, "Orphan shortcuts: %d\n", len(report.Orphans))
+	for _, shortcut := range report.Orphans {
+		cli.Printf(cli.ColorWarn, "  %s (%s)\n", shortcut.Name, shortcut.RelativePath)
+	}
+	cli.Printf(cli.ColorDefault, "\n")
+
+	cli.Printf(cli.ColorDefault, "Files with issues: %d\n", len(report.Files))
+	for _, file := range report.Files {
+		cli.Printf(cli.ColorWarn, "  %-18s %s\n", file.Status, file.RelativePath)
+	}
+	cli.Printf(cli.ColorDefault, "\n")
+
+	// Remove orphan shortcuts when requested
+	if prune {
+		err = management.PruneOrphanShortcuts(report)
+		if err != nil {
+			return err
+		}
+	}
+
+	cli.Printf(cli.ColorSuccess, "Report finished!\n")
+	return nil
+}
+
 // Process ROMs
 func processROMs(context Context) error {
 
@@ -663,6 +1163,75 @@ func processROMs(context Context) error {
 	return nil
 }
 
+// List, set or delete emulator override of ROM
+func overrideROM(context Context) error {
+
+	// Retrieve command details
+	path := context.Arg("--path", "")
+	delete := context.Flag("--delete", false)
+
+	// Init user library
+	err := management.InitLibrary()
+	if err != nil {
+		return err
+	}
+
+	// List overrides when no ROM is given
+	if path == "" {
+		overrides, err := management.GetROMOverrides()
+		if err != nil {
+			return err
+		}
+
+		for _, override := range overrides {
+			cli.Printf(cli.ColorDefault, "%s\n", override.RelativePath)
+			cli.Printf(cli.ColorDefault, "  Emulator: %s\n", override.Emulator)
+			cli.Printf(cli.ColorDefault, "  Arguments: %s\n", override.Arguments)
+			cli.Printf(cli.ColorDefault, "  Environment: %s\n", strings.Join(override.Environment, " "))
+		}
+
+		return nil
+	}
+
+	// Load user library
+	err = management.LoadLibrary()
+	if err != nil {
+		return err
+	}
+
+	// Make sure to save library on finish
+	defer func() {
+		errors.Join(err, management.SaveLibrary())
+	}()
+
+	// Delete override when requested
+	if delete {
+		err = management.RemoveROMOverride(path)
+		if err != nil {
+			return err
+		}
+
+		cli.Printf(cli.ColorSuccess, "ROM override deleted!\n")
+		return nil
+	}
+
+	// Add or update override
+	override := &console.Override{
+		RelativePath: path,
+		Emulator:     context.Arg("--emulator", ""),
+		Arguments:    context.Arg("--arguments", ""),
+		Environment:  context.Multiple("--environment", ","),
+	}
+
+	err = management.SetROMOverride(override)
+	if err != nil {
+		return err
+	}
+
+	cli.Printf(cli.ColorSuccess, "ROM override saved!\n")
+	return nil
+}
+
 // Run server
 func runServer(context Context) error {
 
@@ -42,6 +42,8 @@ func Run(args []string, done chan bool) error {
 		err = scrapeData(context)
 	case "sync":
 		err = syncLibrary(context)
+	case "import-desktop":
+		err = importDesktop(context)
 	case "launch":
 		err = launchShortcut(context)
 	case "create":
@@ -52,16 +54,34 @@ func Run(args []string, done chan bool) error {
 		err = modifyShortcut(context)
 	case "install":
 		err = installPrograms(context)
+	case "outdated":
+		err = listOutdated(context)
+	case "rollback":
+		err = rollbackProgram(context)
 	case "remove":
 		err = removePrograms(context)
+	case "apply":
+		err = applyProfile(context)
+	case "export-profile":
+		err = exportProfile(context)
+	case "configure":
+		err = configurePrograms(context)
 	case "list-state":
 		err = listState(context)
 	case "backup-state":
 		err = backupState(context)
 	case "restore-state":
 		err = restoreState(context)
+	case "verify-state":
+		err = verifyState(context)
+	case "check-bios":
+		err = checkBIOS(context)
+	case "roms-report":
+		err = reportROMs(context)
 	case "process-roms":
 		err = processROMs(context)
+	case "rom-override":
+		err = overrideROM(context)
 	case "server":
 		err = runServer(context)
 	default:
@@ -0,0 +1,238 @@
+package desktop
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/desktop/linux"
+	"github.com/mateussouzaweb/nicedeck/src/desktop/macos"
+	"github.com/mateussouzaweb/nicedeck/src/desktop/windows"
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+	"github.com/mateussouzaweb/nicedeck/src/shortcuts"
+)
+
+// Import options struct
+// Folders are scanned for game entries, while entries are always imported
+type Import struct {
+	Enabled bool     `json:"enabled"`
+	Folders []string `json:"folders"`
+	Entries []string `json:"entries"`
+}
+
+// Retrieve default folders to import entries from on each system
+func DefaultFolders() []string {
+	if cli.IsLinux() {
+		return []string{"$SHARE/applications"}
+	} else if cli.IsMacOS() {
+		return []string{"/Applications", "$HOME/Applications"}
+	} else if cli.IsWindows() {
+		return []string{"$START_MENU"}
+	}
+
+	return []string{}
+}
+
+// Read system entry from path and convert to shortcut
+// Returns nil shortcut when the entry cannot be launched
+func readEntry(path string) (*Shortcut, bool, error) {
+
+	var shortcut *Shortcut
+	var isGame bool
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if cli.IsLinux() && filepath.Ext(path) == ".desktop" {
+		entry, err := linux.ReadDesktopFile(path)
+		if err != nil {
+			return nil, false, err
+		}
+		if entry.Type != "Application" || entry.Hidden || entry.NoDisplay {
+			return nil, false, nil
+		}
+
+		executable, arguments := linux.ParseExec(entry.Exec)
+		if executable == "" {
+			return nil, false, nil
+		}
+
+		for index, argument := range arguments {
+			arguments[index] = cli.Quote(argument)
+		}
+
+		startDirectory := entry.Path
+		if startDirectory == "" && filepath.IsAbs(executable) {
+			startDirectory = filepath.Dir(executable)
+		}
+
+		iconPath := ""
+		if filepath.IsAbs(entry.Icon) {
+			iconPath = entry.Icon
+		}
+
+		isGame = slices.Contains(entry.Categories, "Game")
+		shortcut = &Shortcut{
+			Name:           entry.Name,
+			Description:    entry.Comment,
+			StartDirectory: cli.Quote(startDirectory),
+			Executable:     cli.Quote(executable),
+			LaunchOptions:  strings.Join(arguments, " "),
+			IconPath:       iconPath,
+		}
+	}
+
+	if cli.IsMacOS() && filepath.Ext(path) == ".app" {
+		category, err := macos.ReadBundleCategory(path)
+		if err != nil {
+			return nil, false, err
+		}
+
+		// Games have a category like public.app-category.games or *-games
+		isGame = strings.HasSuffix(category, "games")
+		shortcut = &Shortcut{
+			Name:           strings.TrimSuffix(filepath.Base(path), ".app"),
+			StartDirectory: cli.Quote(filepath.Dir(path)),
+			Executable:     cli.Quote(path),
+		}
+	}
+
+	if cli.IsWindows() && filepath.Ext(path) == ".lnk" {
+		target, err := windows.ReadLnkPath(path)
+		if err != nil {
+			return nil, false, err
+		}
+		if !strings.EqualFold(filepath.Ext(target), ".exe") {
+			return nil, false, nil
+		}
+
+		// Start Menu does not have categories, folder name is used instead
+		folder := strings.ToLower(filepath.Base(filepath.Dir(path)))
+		isGame = folder == "games" || folder == "gaming"
+		shortcut = &Shortcut{
+			Name:           strings.TrimSuffix(filepath.Base(path), ".lnk"),
+			StartDirectory: cli.Quote(filepath.Dir(target)),
+			Executable:     cli.Quote(target),
+		}
+	}
+
+	if shortcut == nil || shortcut.Name == "" {
+		return nil, false, nil
+	}
+
+	if shortcut.Description == "" {
+		shortcut.Description = "Imported from desktop entry"
+	}
+
+	// Modification time allows changes on the entry to be tracked
+	shortcut.ID = shortcuts.GenerateID(shortcut.Name, shortcut.Executable)
+	shortcut.Timestamp = info.ModTime().UTC().Unix()
+	shortcut.Tags = []string{"Desktop"}
+	if isGame {
+		shortcut.Tags = append(shortcut.Tags, "Gaming")
+	}
+
+	return shortcut, isGame, nil
+}
+
+// Read entries to import based on given options
+// Game entries from folders and entries chosen by the user are returned
+// Entries with path in the ignore list are skipped
+func ReadEntries(options *Import, ignore []string) (map[string]*Shortcut, error) {
+
+	results := make(map[string]*Shortcut, 0)
+	if !options.Enabled {
+		return results, nil
+	}
+
+	// Normalize paths for comparison
+	normalize := func(path string) string {
+		return filepath.Clean(fs.ExpandPath(path))
+	}
+
+	chosen := []string{}
+	for _, entry := range options.Entries {
+		chosen = append(chosen, normalize(entry))
+	}
+
+	ignored := []string{}
+	for _, path := range ignore {
+		if path != "" {
+			ignored = append(ignored, normalize(path))
+		}
+	}
+
+	// Helper to append entry when applicable
+	process := func(path string) {
+		if slices.Contains(ignored, path) {
+			return
+		}
+		if _, ok := results[path]; ok {
+			return
+		}
+
+		shortcut, isGame, err := readEntry(path)
+		if err != nil {
+			cli.Debug("Could not read desktop entry %s: %s\n", path, err)
+			return
+		} else if shortcut == nil {
+			return
+		}
+
+		if isGame || slices.Contains(chosen, path) {
+			results[path] = shortcut
+		}
+	}
+
+	// Scan folders for entries
+	folders := options.Folders
+	if len(folders) == 0 {
+		folders = DefaultFolders()
+	}
+
+	for _, folder := range folders {
+		folder = normalize(folder)
+
+		exist, err := fs.DirectoryExist(folder)
+		if err != nil {
+			return results, err
+		} else if !exist {
+			continue
+		}
+
+		err = filepath.WalkDir(folder, func(path string, entry os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			extension := filepath.Ext(path)
+			if entry.IsDir() && extension == ".app" {
+				process(path)
+				return filepath.SkipDir
+			} else if entry.IsDir() {
+				return nil
+			}
+
+			if extension == ".desktop" || extension == ".lnk" {
+				process(path)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return results, fmt.Errorf("could not scan folder %s: %w", folder, err)
+		}
+	}
+
+	// Chosen entries are imported even when outside scanned folders
+	for _, path := range chosen {
+		process(path)
+	}
+
+	return results, nil
+}
@@ -15,9 +15,14 @@ import (
 type Shortcut = shortcuts.Shortcut
 
 // Library struct
+// Imported maps shortcut ID to the source entry path
 type Library struct {
 	DatabasePath string            `json:"databasePath"`
 	References   map[string]string `json:"references"`
+	Import       *Import           `json:"import"`
+	Imported     map[string]string `json:"imported"`
+	entries      []*Shortcut
+	stale        []string
 }
 
 // String representation of the library
@@ -26,7 +31,11 @@ func (l *Library) String() string {
 }
 
 // Operation mode of the library
+// Import mode is used when enabled or when previous imports must be removed
 func (l *Library) Mode() string {
+	if l.Import.Enabled || len(l.stale) > 0 {
+		return "IMPORT"
+	}
 	return "PARTIAL"
 }
 
@@ -41,6 +50,10 @@ func (l *Library) Load() error {
 
 	// Reset and fill basic information
 	l.References = make(map[string]string, 0)
+	l.Import = &Import{}
+	l.Imported = make(map[string]string, 0)
+	l.entries = make([]*Shortcut, 0)
+	l.stale = make([]string, 0)
 
 	// Read database file content
 	err := fs.ReadJSON(l.DatabasePath, &l)
@@ -48,9 +61,59 @@ func (l *Library) Load() error {
 		return err
 	}
 
+	// Values can be null on database file
+	if l.References == nil {
+		l.References = make(map[string]string, 0)
+	}
+	if l.Import == nil {
+		l.Import = &Import{}
+	}
+	if l.Imported == nil {
+		l.Imported = make(map[string]string, 0)
+	}
+
+	// Read entries to import, skipping the ones created by the library itself
+	ignore := make([]string, 0)
+	for _, path := range l.References {
+		ignore = append(ignore, path)
+	}
+
+	entries, err := ReadEntries(l.Import, ignore)
+	if err != nil {
+		return err
+	}
+
+	imported := make(map[string]string, 0)
+	for path, shortcut := range entries {
+		if _, ok := imported[shortcut.ID]; ok {
+			continue
+		}
+		imported[shortcut.ID] = path
+		l.entries = append(l.entries, shortcut)
+	}
+
+	// Entries no longer available are stale and must be removed
+	for id := range l.Imported {
+		if _, ok := imported[id]; !ok {
+			l.stale = append(l.stale, id)
+			delete(l.References, id)
+		}
+	}
+
+	l.Imported = imported
+
 	return nil
 }
 
+// Configure import options
+// Import is disabled when options are not provided
+func (l *Library) Configure(options *Import) {
+	if options == nil {
+		options = &Import{}
+	}
+	l.Import = options
+}
+
 // Save library
 func (l *Library) Save() error {
 
@@ -64,12 +127,17 @@ func (l *Library) Save() error {
 }
 
 // Export shortcuts to internal format
+// Imported entries are exported with full details
 func (l *Library) Export() []*Shortcut {
 	results := make([]*Shortcut, 0)
 	for id := range l.References {
-		results = append(results, &Shortcut{ID: id})
+		if _, ok := l.Imported[id]; !ok {
+			results = append(results, &Shortcut{ID: id})
+		}
 	}
 
+	results = append(results, l.entries...)
+
 	return results
 }
 
@@ -82,6 +150,12 @@ func (l *Library) Add(shortcut *Shortcut) error {
 		return nil
 	}
 
+	// Imported entries already exist on the system
+	if _, ok := l.Imported[shortcut.ID]; ok {
+		l.References[shortcut.ID] = ""
+		return nil
+	}
+
 	// Update shortcut if already present
 	if l.References[shortcut.ID] != "" {
 		return l.Update(shortcut, true)
@@ -121,6 +195,12 @@ func (l *Library) Update(shortcut *Shortcut, overwriteAssets bool) error {
 		return l.Remove(shortcut)
 	}
 
+	// Imported entries already exist on the system
+	if _, ok := l.Imported[shortcut.ID]; ok {
+		l.References[shortcut.ID] = ""
+		return nil
+	}
+
 	// Add shortcut if not present yet
 	if l.References[shortcut.ID] == "" {
 		return l.Add(shortcut)
@@ -213,3 +213,50 @@ func WriteDesktopFile(destination string, entry *DesktopEntry) error {
 
 	return nil
 }
+
+// Split the Exec value of a desktop entry into executable and arguments
+// Field codes like %f, %U or %i are removed since they are filled by launchers
+func ParseExec(exec string) (string, []string) {
+
+	parts := []string{}
+	current := strings.Builder{}
+	quoted := false
+	escaped := false
+
+	for _, char := range exec {
+		switch {
+		case escaped:
+			current.WriteRune(char)
+			escaped = false
+		case char == '\\':
+			escaped = true
+		case char == '"':
+			quoted = !quoted
+		case char == ' ' && !quoted:
+			if current.Len() > 0 {
+				parts = append(parts, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(char)
+		}
+	}
+	if current.Len() > 0 {
+		parts = append(parts, current.String())
+	}
+
+	// Remove field codes and unescape literal percent signs
+	arguments := []string{}
+	for _, part := range parts {
+		if len(part) == 2 && part[0] == '%' && part[1] != '%' {
+			continue
+		}
+		arguments = append(arguments, strings.ReplaceAll(part, "%%", "%"))
+	}
+
+	if len(arguments) == 0 {
+		return "", arguments
+	}
+
+	return arguments[0], arguments[1:]
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/mateussouzaweb/nicedeck/src/cli"
@@ -162,3 +163,22 @@ func WriteBundle(destination string, bundle *Bundle) error {
 
 	return nil
 }
+
+// Read the application category type declared in the bundle Info.plist file
+// Returns empty value when the category is not declared
+func ReadBundleCategory(destination string) (string, error) {
+
+	infoPlistPath := filepath.Join(destination, "Contents", "Info.plist")
+	content, err := os.ReadFile(infoPlistPath)
+	if err != nil {
+		return "", err
+	}
+
+	pattern := regexp.MustCompile(`<key>LSApplicationCategoryType</key>\s*<string>([^<]*)</string>`)
+	match := pattern.FindStringSubmatch(string(content))
+	if len(match) < 2 {
+		return "", nil
+	}
+
+	return strings.TrimSpace(match[1]), nil
+}
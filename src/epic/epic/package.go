@@ -0,0 +1,33 @@
+package epic
+
+import (
+	"github.com/mateussouzaweb/nicedeck/src/packaging"
+	"github.com/mateussouzaweb/nicedeck/src/packaging/macos"
+	"github.com/mateussouzaweb/nicedeck/src/packaging/proton"
+	"github.com/mateussouzaweb/nicedeck/src/packaging/windows"
+	"github.com/mateussouzaweb/nicedeck/src/programs/website"
+)
+
+// Retrieve Epic Games package
+func GetPackage() packaging.Package {
+	return packaging.Best(&proton.Proton{
+		AppID:       "epic-games",
+		Installer:   "$DOWNLOADS/EpicGamesLauncherInstaller.msi",
+		Uninstaller: "$DOWNLOADS/EpicGamesLauncherInstaller.msi",
+		Launcher:    "$PROGRAMS/Epic Games/Launcher/Portal/Binaries/Win64/EpicGamesLauncher.exe",
+		Arguments: &packaging.Arguments{
+			Install:  []string{"-opengl"},
+			Remove:   []string{"-opengl"},
+			Shortcut: []string{"-opengl"},
+		},
+		Source: website.Link("https://launcher-public-service-prod06.ol.epicgames.com/launcher/api/installer/download/EpicGamesLauncherInstaller.msi"),
+	}, &macos.Homebrew{
+		AppID:     "epic-games",
+		Launcher:  "/Applications/Epic Games Launcher.app",
+		Arguments: packaging.NoArguments(),
+	}, &windows.WinGet{
+		AppID:     "EpicGames.EpicGamesLauncher",
+		Launcher:  "$PROGRAMS/Epic Games/Launcher/Portal/Binaries/Win64/EpicGamesLauncher.exe",
+		Arguments: packaging.NoArguments(),
+	})
+}
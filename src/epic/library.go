@@ -0,0 +1,121 @@
+package epic
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/epic/epic"
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+	"github.com/mateussouzaweb/nicedeck/src/shortcuts"
+)
+
+// Shortcut alias
+type Shortcut = shortcuts.Shortcut
+
+// Library struct
+type Library struct {
+	DatabasePath string      `json:"databasePath"`
+	Games        []*Manifest `json:"games"`
+}
+
+// String representation of the library
+func (l *Library) String() string {
+	return "Epic Games Launcher"
+}
+
+// Operation mode of the library
+func (l *Library) Mode() string {
+	return "IMPORT"
+}
+
+// Init library
+func (l *Library) Init(databasePath string) error {
+	l.DatabasePath = databasePath
+	return nil
+}
+
+// Load library
+func (l *Library) Load() error {
+
+	// Reset and fill basic information
+	l.Games = make([]*Manifest, 0)
+
+	// Check if Epic Games is installed
+	epicPackage := epic.GetPackage()
+	installed, err := epicPackage.Installed()
+	if err != nil {
+		return err
+	} else if !installed {
+		return nil
+	}
+
+	// Read installed games from launcher manifests
+	games, err := GetInstalledGames(GetManifestsPath(epicPackage))
+	if err != nil {
+		return err
+	}
+
+	l.Games = games
+	return nil
+}
+
+// Save library
+func (l *Library) Save() error {
+
+	// Save database state to file
+	err := fs.WriteJSON(l.DatabasePath, l)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Export shortcuts to internal format
+// Installed games launch through the launcher protocol
+func (l *Library) Export() []*Shortcut {
+
+	results := make([]*Shortcut, 0)
+	epicPackage := epic.GetPackage()
+	executable := epicPackage.Executable()
+
+	for _, game := range l.Games {
+		arguments := append([]string{}, epicPackage.Args()...)
+		arguments = append(arguments, cli.Quote(fmt.Sprintf(
+			"com.epicgames.launcher://apps/%s%%3A%s%%3A%s?action=launch&silent=true",
+			game.CatalogNamespace, game.CatalogItemId, game.AppName,
+		)))
+
+		results = append(results, &Shortcut{
+			ID:             shortcuts.GenerateID(game.DisplayName, executable),
+			Program:        "epic-games",
+			Name:           game.DisplayName,
+			Description:    "Game from Epic Games",
+			StartDirectory: cli.Quote(filepath.Dir(executable)),
+			Executable:     cli.Quote(executable),
+			LaunchOptions:  strings.Join(arguments, " "),
+			RelativePath:   "epic/" + game.AppName,
+			Tags:           []string{l.String()},
+		})
+	}
+
+	return results
+}
+
+// Add shortcut to the library
+// Games are managed by the launcher itself, nothing to do here
+func (l *Library) Add(shortcut *Shortcut) error {
+	return nil
+}
+
+// Update shortcut on library
+func (l *Library) Update(shortcut *Shortcut, overwriteAssets bool) error {
+	return nil
+}
+
+// Remove shortcut from the library
+func (l *Library) Remove(shortcut *Shortcut) error {
+	return nil
+}
@@ -0,0 +1,84 @@
+package epic
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+	"github.com/mateussouzaweb/nicedeck/src/packaging"
+	"github.com/mateussouzaweb/nicedeck/src/packaging/proton"
+)
+
+// Manifest struct
+type Manifest struct {
+	AppName             string   `json:"AppName"`
+	MainGameAppName     string   `json:"MainGameAppName"`
+	DisplayName         string   `json:"DisplayName"`
+	InstallLocation     string   `json:"InstallLocation"`
+	LaunchExecutable    string   `json:"LaunchExecutable"`
+	CatalogNamespace    string   `json:"CatalogNamespace"`
+	CatalogItemId       string   `json:"CatalogItemId"`
+	AppCategories       []string `json:"AppCategories"`
+	IsIncompleteInstall bool     `json:"bIsIncompleteInstall"`
+}
+
+// Retrieve manifests folder path for the given package
+func GetManifestsPath(target packaging.Package) string {
+	if target.Runtime() == "proton" {
+		return proton.ResolvePath(target, "C:/ProgramData/Epic/EpicGamesLauncher/Data/Manifests")
+	} else if cli.IsMacOS() {
+		return fs.ExpandPath("$CONFIG/Epic/EpicGamesLauncher/Data/Manifests")
+	} else if cli.IsWindows() {
+		return fs.ExpandPath("$HOMEDRIVE/ProgramData/Epic/EpicGamesLauncher/Data/Manifests")
+	}
+
+	return ""
+}
+
+// Read installed games from manifest files in given folder
+// Incomplete installations, DLCs and non-game applications are ignored
+func GetInstalledGames(manifestsPath string) ([]*Manifest, error) {
+
+	games := []*Manifest{}
+
+	exist, err := fs.DirectoryExist(manifestsPath)
+	if err != nil {
+		return games, err
+	} else if !exist {
+		return games, nil
+	}
+
+	entries, err := os.ReadDir(manifestsPath)
+	if err != nil {
+		return games, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".item") {
+			continue
+		}
+
+		manifest := &Manifest{}
+		err := fs.ReadJSON(filepath.Join(manifestsPath, entry.Name()), manifest)
+		if err != nil {
+			return games, err
+		}
+
+		if manifest.AppName == "" || manifest.IsIncompleteInstall {
+			continue
+		}
+		if manifest.MainGameAppName != "" && manifest.MainGameAppName != manifest.AppName {
+			continue
+		}
+		if len(manifest.AppCategories) > 0 && !slices.Contains(manifest.AppCategories, "games") {
+			continue
+		}
+
+		games = append(games, manifest)
+	}
+
+	return games, nil
+}
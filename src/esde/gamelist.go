@@ -0,0 +1,266 @@
+package esde
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+)
+
+// Sample XML for gamelist.xml
+// <?xml version="1.0"?>
+// <alternativeEmulator>
+//     <label>MGBA</label>
+// </alternativeEmulator>
+// <gameList>
+//     <game>
+//         <path>./Game.gba</path>
+//         <name>Game</name>
+//         <desc>Description</desc>
+//         <favorite>true</favorite>
+//     </game>
+// </gameList>
+
+// Game struct
+// Path is relative to the system ROMs folder
+type Game struct {
+	System      string `json:"system"`
+	Path        string `json:"path"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	CoverPath   string `json:"coverPath"`
+	LogoPath    string `json:"logoPath"`
+	HeroPath    string `json:"heroPath"`
+}
+
+// GameList represents the root element of gamelist.xml
+// Unknown elements are kept to preserve data managed by ES-DE
+type GameList struct {
+	XMLName xml.Name      `xml:"gameList"`
+	Games   []GameElement `xml:"game"`
+	Others  []Element     `xml:",any"`
+}
+
+// GameElement represents a single game in gamelist.xml
+type GameElement struct {
+	XMLName xml.Name  `xml:"game"`
+	Path    string    `xml:"path"`
+	Name    string    `xml:"name"`
+	Desc    string    `xml:"desc,omitempty"`
+	Others  []Element `xml:",any"`
+}
+
+// Element represents any other XML element
+type Element struct {
+	XMLName    xml.Name
+	Attributes []xml.Attr `xml:",any,attr"`
+	Content    string     `xml:",innerxml"`
+}
+
+// Media folders of ES-DE and respective image on game
+var mediaFolders = map[string]func(game *Game) string{
+	"covers": func(game *Game) string {
+		return game.CoverPath
+	},
+	"marquees": func(game *Game) string {
+		return game.LogoPath
+	},
+	"fanart": func(game *Game) string {
+		return game.HeroPath
+	},
+}
+
+// Read gamelist file content
+// File can have other root elements before the game list
+func ReadGamelist(path string) (*GameList, []Element, error) {
+
+	gameList := &GameList{}
+	others := []Element{}
+
+	exist, err := fs.FileExist(path)
+	if err != nil {
+		return gameList, others, err
+	} else if !exist {
+		return gameList, others, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return gameList, others, err
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return gameList, others, err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		if start.Name.Local == "gameList" {
+			err = decoder.DecodeElement(gameList, &start)
+		} else {
+			element := Element{}
+			err = decoder.DecodeElement(&element, &start)
+			others = append(others, element)
+		}
+		if err != nil {
+			return gameList, others, err
+		}
+	}
+
+	return gameList, others, nil
+}
+
+// Write gamelist file content
+func WriteGamelistFile(path string, gameList *GameList, others []Element) error {
+
+	content := []byte(xml.Header)
+	for _, element := range others {
+		output, err := xml.MarshalIndent(element, "", "  ")
+		if err != nil {
+			return err
+		}
+		content = append(content, output...)
+		content = append(content, '\n')
+	}
+
+	output, err := xml.MarshalIndent(gameList, "", "  ")
+	if err != nil {
+		return err
+	}
+	content = append(content, output...)
+	content = append(content, '\n')
+
+	return fs.WriteFile(path, string(content))
+}
+
+// Write gamelist of the system with given games
+// Entries with path in the removed list are removed from gamelist
+func WriteGamelist(basePath string, system string, games []*Game, removed []string) error {
+
+	gamelistPath := filepath.Join(basePath, "gamelists", system, "gamelist.xml")
+	gameList, others, err := ReadGamelist(gamelistPath)
+	if err != nil {
+		return err
+	}
+
+	gameList.Games = slices.DeleteFunc(gameList.Games, func(item GameElement) bool {
+		return slices.Contains(removed, item.Path)
+	})
+
+	// Update existing entries or append new entries
+	for _, game := range games {
+		index := slices.IndexFunc(gameList.Games, func(item GameElement) bool {
+			return item.Path == game.Path
+		})
+
+		if index == -1 {
+			gameList.Games = append(gameList.Games, GameElement{
+				Path: game.Path,
+				Name: game.Name,
+				Desc: game.Description,
+			})
+			continue
+		}
+
+		gameList.Games[index].Name = game.Name
+		if game.Description != "" {
+			gameList.Games[index].Desc = game.Description
+		}
+	}
+
+	return WriteGamelistFile(gamelistPath, gameList, others)
+}
+
+// Retrieve media path of the game on given folder
+// Extension is not included since it depends on the image file
+func getMediaPath(basePath string, folder string, game *Game) string {
+	relativePath := strings.TrimPrefix(game.Path, "./")
+	relativePath = strings.TrimSuffix(relativePath, filepath.Ext(relativePath))
+	return filepath.Join(basePath, "downloaded_media", game.System, folder, relativePath)
+}
+
+// Remove media of the game from every media folder
+func RemoveMedia(basePath string, game *Game) error {
+
+	for folder := range mediaFolders {
+		mediaPath := getMediaPath(basePath, folder, game)
+		for _, extension := range []string{".png", ".jpg"} {
+			err := fs.RemoveSymlink(mediaPath + extension)
+			if err != nil {
+				return err
+			}
+			err = fs.RemoveFile(mediaPath + extension)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Write media of the game into every media folder
+// Symbolic links are used when supported, otherwise images are copied
+func WriteMedia(basePath string, game *Game) error {
+
+	for folder, getImage := range mediaFolders {
+
+		image := getImage(game)
+		if image == "" {
+			continue
+		}
+
+		exist, err := fs.FileExist(image)
+		if err != nil {
+			return err
+		} else if !exist {
+			continue
+		}
+
+		basename := getMediaPath(basePath, folder, game)
+		mediaPath := basename + filepath.Ext(image)
+
+		// Skip when link already points to the image
+		if target, err := os.Readlink(mediaPath); err == nil && target == image {
+			continue
+		}
+
+		// Remove previous media that could have another extension
+		for _, extension := range []string{".png", ".jpg"} {
+			previous := basename + extension
+			err := fs.RemoveSymlink(previous)
+			if err != nil {
+				return err
+			}
+			err = fs.RemoveFile(previous)
+			if err != nil {
+				return err
+			}
+		}
+
+		if cli.IsWindows() {
+			err = fs.CopyFile(image, mediaPath, true)
+		} else {
+			err = fs.MakeSymlink(image, mediaPath)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
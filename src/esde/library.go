@@ -1,10 +1,16 @@
 package esde
 
 import (
+	"path/filepath"
+	"slices"
+	"strings"
+
 	"github.com/mateussouzaweb/nicedeck/src/cli"
 	"github.com/mateussouzaweb/nicedeck/src/esde/esde"
 	"github.com/mateussouzaweb/nicedeck/src/esde/settings"
 	"github.com/mateussouzaweb/nicedeck/src/fs"
+	"github.com/mateussouzaweb/nicedeck/src/platforms"
+	"github.com/mateussouzaweb/nicedeck/src/platforms/console"
 	"github.com/mateussouzaweb/nicedeck/src/shortcuts"
 )
 
@@ -12,10 +18,14 @@ import (
 type Shortcut = shortcuts.Shortcut
 
 // Library struct
+// Games contains the gamelist data of ROM shortcuts
 type Library struct {
 	DatabasePath string            `json:"databasePath"`
 	BasePath     string            `json:"basePath"`
 	References   map[string]string `json:"references"`
+	Games        map[string]*Game  `json:"games"`
+	removed      []*Game
+	consoles     []*console.Platform
 }
 
 // String representation of the library
@@ -40,6 +50,8 @@ func (l *Library) Load() error {
 	// Reset and fill basic information
 	l.BasePath = ""
 	l.References = make(map[string]string, 0)
+	l.Games = nil
+	l.removed = make([]*Game, 0)
 
 	// Read database file content
 	err := fs.ReadJSON(l.DatabasePath, &l)
@@ -47,6 +59,13 @@ func (l *Library) Load() error {
 		return err
 	}
 
+	// Database without games was created before gamelist support
+	// Reset references so every shortcut is added again on sync
+	if l.Games == nil {
+		l.Games = make(map[string]*Game, 0)
+		l.References = make(map[string]string, 0)
+	}
+
 	// Windows portable version uses application path
 	if cli.IsWindows() {
 		l.BasePath = fs.ExpandPath("$APPLICATIONS/ES-DE/ES-DE")
@@ -93,9 +112,120 @@ func (l *Library) Save() error {
 		return err
 	}
 
+	// Write gamelists and media
+	err = l.writeGames()
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// Write gamelists and media of games into ES-DE folders
+func (l *Library) writeGames() error {
+
+	// Group games and removed entries per system
+	systems := make(map[string][]*Game, 0)
+	removed := make(map[string][]string, 0)
+
+	for _, game := range l.Games {
+		systems[game.System] = append(systems[game.System], game)
+	}
+	for _, game := range l.removed {
+		removed[game.System] = append(removed[game.System], game.Path)
+		if _, ok := systems[game.System]; !ok {
+			systems[game.System] = []*Game{}
+		}
+	}
+
+	for system, games := range systems {
+		err := WriteGamelist(l.BasePath, system, games, removed[system])
+		if err != nil {
+			return err
+		}
+	}
+
+	// Remove media before writing to handle games that moved
+	for _, game := range l.removed {
+		err := RemoveMedia(l.BasePath, game)
+		if err != nil {
+			return err
+		}
+	}
+	for _, game := range l.Games {
+		err := WriteMedia(l.BasePath, game)
+		if err != nil {
+			return err
+		}
+	}
+
+	l.removed = make([]*Game, 0)
+
+	return nil
+}
+
+// Convert ROM shortcut to game entry
+// Returns nil when shortcut is not from a known ROM platform folder
+func (l *Library) toGame(shortcut *Shortcut) *Game {
+
+	if !slices.Contains(shortcut.Tags, "ROM") || shortcut.RelativePath == "" {
+		return nil
+	}
+
+	// ROM relative path starts with the platform folder
+	// ROMs from additional roots start with the root ID and are skipped
+	relativePath := filepath.ToSlash(shortcut.RelativePath)
+	folder, path, found := strings.Cut(relativePath, "/")
+	if !found {
+		return nil
+	}
+
+	// Platforms are read once and reused for next shortcuts
+	if l.consoles == nil {
+		consoles, err := platforms.GetConsoles()
+		if err != nil {
+			cli.Debug("Could not retrieve platforms: %s\n", err)
+			return nil
+		}
+		l.consoles = consoles
+	}
+
+	for _, platform := range l.consoles {
+		if platform.Folder != folder {
+			continue
+		}
+
+		return &Game{
+			System:      strings.ToLower(platform.Name),
+			Path:        "./" + path,
+			Name:        shortcut.Name,
+			Description: shortcut.Description,
+			CoverPath:   shortcut.CoverPath,
+			LogoPath:    shortcut.LogoPath,
+			HeroPath:    shortcut.HeroPath,
+		}
+	}
+
+	return nil
+}
+
+// Update game entry from shortcut data
+func (l *Library) updateGame(shortcut *Shortcut) {
+
+	game := l.toGame(shortcut)
+	previous, exists := l.Games[shortcut.ID]
+
+	// Previous entry is removed when game was moved or is not a ROM anymore
+	if exists && (game == nil || previous.System != game.System || previous.Path != game.Path) {
+		l.removed = append(l.removed, previous)
+		delete(l.Games, shortcut.ID)
+	}
+
+	if game != nil {
+		l.Games[shortcut.ID] = game
+	}
+}
+
 // Export shortcuts to internal format
 func (l *Library) Export() []*Shortcut {
 	results := make([]*Shortcut, 0)
@@ -109,17 +239,24 @@ func (l *Library) Export() []*Shortcut {
 // Add shortcut to the library
 func (l *Library) Add(shortcut *Shortcut) error {
 	l.References[shortcut.ID] = ""
+	l.updateGame(shortcut)
 	return nil
 }
 
 // Update shortcut on library
 func (l *Library) Update(shortcut *Shortcut, overwriteAssets bool) error {
 	l.References[shortcut.ID] = ""
+	l.updateGame(shortcut)
 	return nil
 }
 
 // Remove shortcut from the library
 func (l *Library) Remove(shortcut *Shortcut) error {
+	if game, ok := l.Games[shortcut.ID]; ok {
+		l.removed = append(l.removed, game)
+		delete(l.Games, shortcut.ID)
+	}
+
 	delete(l.References, shortcut.ID)
 	return nil
 }
@@ -3,10 +3,12 @@ package settings
 import (
 	"encoding/xml"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/mateussouzaweb/nicedeck/src/fs"
 	"github.com/mateussouzaweb/nicedeck/src/platforms"
+	"github.com/mateussouzaweb/nicedeck/src/retroarch"
 )
 
 // Sample XML for es_find_rules.xml
@@ -25,12 +27,18 @@ import (
 //             <entry>$EMULATORS/Azahar/azahar.AppImage</entry>
 //         </rule>
 //     </emulator>
+//     <core name="RETROARCH">
+//         <rule type="corepath">
+//             <entry>$CONFIG/retroarch/cores</entry>
+//         </rule>
+//     </core>
 // </ruleList>
 
 // Represents the root element of es_find_rules.xml
 type RuleList struct {
 	XMLName   xml.Name   `xml:"ruleList"`
 	Emulators []Emulator `xml:"emulator"`
+	Cores     []Core     `xml:"core"`
 }
 
 // Emulator represents a single emulator configuration
@@ -40,6 +48,13 @@ type Emulator struct {
 	Rules   []Rule   `xml:"rule"`
 }
 
+// Core represents the cores location of a multi-system emulator
+type Core struct {
+	XMLName xml.Name `xml:"core"`
+	Name    string   `xml:"name,attr"`
+	Rules   []Rule   `xml:"rule"`
+}
+
 // Rule represents a single find rule configuration
 type Rule struct {
 	XMLName xml.Name `xml:"rule"`
@@ -73,10 +88,18 @@ func WriteFindRules(destinationPath string) error {
 	}
 
 	// Add emulators to RuleList
+	// Emulators available on multiple platforms are added only once
+	added := []string{}
 	for _, platform := range consolePlatforms {
 		for _, emulator := range platform.Emulators {
+			name := strings.ToUpper(emulator.Name)
+			if slices.Contains(added, name) {
+				continue
+			}
+
+			added = append(added, name)
 			ruleList.Emulators = append(ruleList.Emulators, Emulator{
-				Name: strings.ToUpper(emulator.Name),
+				Name: name,
 				Rules: []Rule{
 					{
 						Type:    "staticpath",
@@ -84,6 +107,19 @@ func WriteFindRules(destinationPath string) error {
 					},
 				},
 			})
+
+			// Add cores path for multi-system emulators
+			if emulator.Core != "" {
+				ruleList.Cores = append(ruleList.Cores, Core{
+					Name: name,
+					Rules: []Rule{
+						{
+							Type:    "corepath",
+							Entries: []string{retroarch.GetCoresPath()},
+						},
+					},
+				})
+			}
 		}
 	}
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/mateussouzaweb/nicedeck/src/fs"
 	"github.com/mateussouzaweb/nicedeck/src/platforms"
+	"github.com/mateussouzaweb/nicedeck/src/retroarch"
 )
 
 // Sample XML for es_systems.xml
@@ -52,23 +53,34 @@ type Command struct {
 
 // Platform to Theme mapping for ES-DE
 var platformThemeMap = map[string]string{
-	"DC":     "dreamcast",
-	"GBA":    "gba",
-	"GC":     "gc",
-	"3DS":    "n3ds",
-	"N64":    "n64",
-	"NDS":    "nds",
-	"PS1":    "psx",
-	"PS2":    "ps2",
-	"PS3":    "ps3",
-	"PS4":    "ps4",
-	"PSP":    "psp",
-	"PSVITA": "psvita",
-	"SWITCH": "switch",
-	"WII":    "wii",
-	"WIIU":   "wiiu",
-	"XBOX":   "xbox",
-	"X360":   "xbox360",
+	"ARCADE":    "arcade",
+	"ATARI2600": "atari2600",
+	"ATARI7800": "atari7800",
+	"GENESIS":   "genesis",
+	"MSX":       "msx",
+	"NEOGEO":    "neogeo",
+	"NES":       "nes",
+	"PCE":       "pcengine",
+	"SATURN":    "saturn",
+	"SMS":       "mastersystem",
+	"SNES":      "snes",
+	"DC":        "dreamcast",
+	"GBA":       "gba",
+	"GC":        "gc",
+	"3DS":       "n3ds",
+	"N64":       "n64",
+	"NDS":       "nds",
+	"PS1":       "psx",
+	"PS2":       "ps2",
+	"PS3":       "ps3",
+	"PS4":       "ps4",
+	"PSP":       "psp",
+	"PSVITA":    "psvita",
+	"SWITCH":    "switch",
+	"WII":       "wii",
+	"WIIU":      "wiiu",
+	"XBOX":      "xbox",
+	"X360":      "xbox360",
 }
 
 // Write systems for ES-DE
@@ -97,10 +109,19 @@ func WriteSystems(destinationPath string) error {
 			extensions = append(extensions, strings.ToUpper(emulator.Extensions))
 
 			// Add commands for the emulator on platform
-			commandValue := fmt.Sprintf("%%EMULATOR_%s%% %s", strings.ToUpper(emulator.Name), emulator.LaunchOptions)
+			label := strings.ToUpper(emulator.Name)
+			commandValue := fmt.Sprintf("%%EMULATOR_%s%% %s", label, emulator.LaunchOptions)
 			commandValue = strings.ReplaceAll(commandValue, "${ROM}", "%ROM%") // Ensure %ROM% is preserved
+
+			// Multi-system emulators load the core from the core path
+			if emulator.Core != "" {
+				corePath := fmt.Sprintf("%%CORE_%s%%/%s", label, retroarch.GetCoreFile(emulator.Core))
+				commandValue = strings.ReplaceAll(commandValue, "${CORE}", corePath)
+				label = fmt.Sprintf("%s (%s)", label, strings.ToUpper(emulator.Core))
+			}
+
 			commands = append(commands, Command{
-				Label: strings.ToUpper(emulator.Name),
+				Label: label,
 				Value: commandValue,
 			})
 
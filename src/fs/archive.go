@@ -8,182 +8,394 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
 	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/fs/lzma"
+	"github.com/mateussouzaweb/nicedeck/src/fs/sevenzip"
 )
 
-// Extract given source .zip file into destination
-func ExtractZip(source string, destination string) error {
+// Extractor struct
+// Extractor writes archive entries inside the destination folder only
+// Entries are written through os.Root, so even existing symbolic links
+// cannot be used to escape from the destination folder
+type extractor struct {
+	destination string
+	root        *os.Root
+}
 
-	cli.Debug("Extracting %s to %s\n", source, destination)
+// Create extractor for given destination folder
+func newExtractor(destination string) (*extractor, error) {
 
-	// Open the archive file
-	archive, err := os.Open(source)
+	err := os.MkdirAll(destination, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := os.OpenRoot(destination)
 	if err != nil {
+		return nil, err
+	}
+
+	return &extractor{destination: destination, root: root}, nil
+}
+
+// Close extractor root folder
+func (e *extractor) Close() error {
+	return e.root.Close()
+}
+
+// Retrieve safe relative path for archive entry name
+// Absolute paths and paths escaping from destination are rejected
+func (e *extractor) path(name string) (string, error) {
+
+	clean := strings.ReplaceAll(name, "\\", "/")
+	if strings.HasPrefix(clean, "/") || filepath.VolumeName(clean) != "" {
+		return "", fmt.Errorf("illegal path in archive: %s", name)
+	}
+
+	clean = path.Clean(clean)
+	if clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("illegal path in archive: %s", name)
+	}
+	if strings.Contains(clean, ":") && cli.IsWindows() {
+		return "", fmt.Errorf("illegal path in archive: %s", name)
+	}
+
+	return filepath.FromSlash(clean), nil
+}
+
+// Make directory and its parents inside destination
+func (e *extractor) mkdirAll(relative string) error {
+
+	if relative == "." {
+		return nil
+	}
+
+	current := ""
+	for part := range strings.SplitSeq(relative, string(os.PathSeparator)) {
+		current = filepath.Join(current, part)
+		err := e.root.Mkdir(current, 0755)
+		if err != nil && !errors.Is(err, os.ErrExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Remove existing symbolic link or file to replace it with a new entry
+func (e *extractor) replace(relative string) error {
+
+	info, err := e.root.Lstat(relative)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
 		return err
+	} else if info.IsDir() {
+		return nil
 	}
 
-	defer func() {
-		errors.Join(err, archive.Close())
-	}()
+	return e.root.Remove(relative)
+}
 
-	// Get file info to determine size for zip.NewReader
-	archiveInfo, err := archive.Stat()
+// Extract directory entry
+func (e *extractor) Directory(name string) error {
+
+	relative, err := e.path(name)
 	if err != nil {
 		return err
 	}
 
-	// Open zip file to see its content
-	archiveReader, err := zip.NewReader(archive, archiveInfo.Size())
+	return e.mkdirAll(relative)
+}
+
+// Extract regular file entry with given content
+func (e *extractor) File(name string, mode os.FileMode, content io.Reader) (err error) {
+
+	relative, err := e.path(name)
 	if err != nil {
 		return err
 	}
 
-	// Process each item of the archive
-	// Files outside of the archive will not be removed
-	for _, file := range archiveReader.File {
+	// Make sure that the parent folder exists
+	err = e.mkdirAll(filepath.Dir(relative))
+	if err != nil {
+		return err
+	}
 
-		// Get content information
-		destinationPath := NormalizePath(file.Name)
-		destinationPath = filepath.Join(destination, destinationPath)
-		isDirectory := file.FileInfo().IsDir()
+	err = e.replace(relative)
+	if err != nil {
+		return err
+	}
 
-		// Make sure that path is not a directory
-		separator := string(os.PathSeparator)
-		if strings.HasSuffix(NormalizePath(file.Name), separator) {
-			isDirectory = true
-		}
+	// Now create the target file
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	file, err := e.root.OpenFile(relative, flags, mode.Perm()|0600)
+	if err != nil {
+		return err
+	}
 
-		// If is a directory, just ensure that the folder exists
-		if isDirectory {
-			err = os.MkdirAll(destinationPath, 0755)
-			if err != nil {
-				return err
-			}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	// Copy file content to target
+	_, err = io.Copy(file, content)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Maximum number of symbolic links followed while resolving a path
+const maxLinks = 40
+
+// Resolve path inside destination following existing symbolic links
+// Fails when the real location of the path escapes from destination
+// Parent references after missing components are rejected, because these
+// components could be created later as links that change the location
+func (e *extractor) resolve(name string) error {
 
+	real := []string{}
+	parts := strings.Split(name, "/")
+	missing := false
+	links := 0
+
+	for len(parts) > 0 {
+		part := parts[0]
+		parts = parts[1:]
+
+		switch part {
+		case "", ".":
+			continue
+		case "..":
+			if len(real) == 0 || missing {
+				return fmt.Errorf("illegal path in archive: %s", name)
+			}
+			real = real[:len(real)-1]
 			continue
 		}
 
-		// If is a regular file, make sure that the parent folder exists
-		err = os.MkdirAll(filepath.Dir(destinationPath), 0755)
-		if err != nil {
+		current := filepath.Join(append(real, part)...)
+		info, err := e.root.Lstat(current)
+		if errors.Is(err, os.ErrNotExist) {
+			missing = true
+			real = append(real, part)
+			continue
+		} else if err != nil {
 			return err
 		}
 
-		// Now create or replace the target file
-		flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
-		fileWriter, err := os.OpenFile(destinationPath, flags, file.Mode())
-		if err != nil {
-			return err
+		if info.Mode()&os.ModeSymlink == 0 {
+			real = append(real, part)
+			continue
 		}
 
-		defer func() {
-			errors.Join(err, fileWriter.Close())
-		}()
+		// Continue from the target of link, relative to its folder
+		links++
+		if links > maxLinks {
+			return fmt.Errorf("too many links in archive: %s", name)
+		}
 
-		// Read file content
-		fileReader, err := file.Open()
+		target, err := os.Readlink(filepath.Join(e.destination, current))
 		if err != nil {
 			return err
 		}
 
-		defer func() {
-			errors.Join(err, fileReader.Close())
-		}()
-
-		// Copy file content to target
-		_, err = io.Copy(fileWriter, fileReader)
-		if err != nil {
-			return err
+		target = strings.ReplaceAll(target, "\\", "/")
+		if strings.HasPrefix(target, "/") || filepath.VolumeName(target) != "" {
+			return fmt.Errorf("illegal path in archive: %s", name)
 		}
 
+		parts = append(strings.Split(target, "/"), parts...)
 	}
 
 	return nil
 }
 
-// Extract given source .tar.gz file content into destination
-func ExtractTarGz(source string, destination string) error {
+// Extract symbolic link entry
+// Links are only created when the target resolves inside destination,
+// following links already extracted to find their real location
+func (e *extractor) Symlink(name string, target string) error {
+
+	relative, err := e.path(name)
+	if err != nil {
+		return err
+	}
+
+	target = strings.ReplaceAll(target, "\\", "/")
+	if target == "" || strings.HasPrefix(target, "/") || filepath.VolumeName(target) != "" {
+		return fmt.Errorf("illegal link target in archive: %s -> %s", name, target)
+	}
+
+	// Make sure that the parent folder exists inside destination
+	parent := filepath.Dir(relative)
+	err = e.mkdirAll(parent)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.root.Stat(parent)
+	if err != nil {
+		return err
+	}
+
+	// Target is resolved from the real location of the link folder
+	err = e.resolve(filepath.ToSlash(parent) + "/" + target)
+	if err != nil {
+		return fmt.Errorf("illegal link target in archive: %s -> %s", name, target)
+	}
+
+	err = e.replace(relative)
+	if err != nil {
+		return err
+	}
+
+	// Parent is already validated by root, so link is created inside it
+	link := filepath.Join(e.destination, relative)
+	err = os.Symlink(filepath.FromSlash(target), link)
+	if err != nil && cli.IsWindows() {
+		cli.Debug("Skipping symbolic link %s: %s\n", name, err)
+		return nil
+	}
+
+	return err
+}
+
+// Extract hard link entry by copying content of the linked file
+func (e *extractor) Hardlink(name string, target string, mode os.FileMode) (err error) {
+
+	relative, err := e.path(target)
+	if err != nil {
+		return err
+	}
+
+	file, err := e.root.Open(relative)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	return e.File(name, mode, file)
+}
+
+// Extract given source .zip file into destination
+func ExtractZip(source string, destination string) (err error) {
 
 	cli.Debug("Extracting %s to %s\n", source, destination)
 
-	// Open the archive file
-	archive, err := os.Open(source)
+	// Open zip file to see its content
+	archive, err := zip.OpenReader(source)
 	if err != nil {
 		return err
 	}
 
 	defer func() {
-		errors.Join(err, archive.Close())
+		err = errors.Join(err, archive.Close())
 	}()
 
-	// Create the gzip reader for archive
-	gzipReader, err := gzip.NewReader(archive)
+	extractor, err := newExtractor(destination)
 	if err != nil {
 		return err
 	}
 
 	defer func() {
-		errors.Join(err, gzipReader.Close())
+		err = errors.Join(err, extractor.Close())
 	}()
 
 	// Process each item of the archive
 	// Files outside of the archive will not be removed
-	tarReader := tar.NewReader(gzipReader)
-	for {
-
-		header, err := tarReader.Next()
-		if err == io.EOF {
-			break // End of archive
-		} else if err != nil {
+	for _, file := range archive.File {
+		err = extractZipFile(extractor, file)
+		if err != nil {
 			return err
-		} else if header == nil {
-			continue
 		}
+	}
 
-		// Get content information
-		destinationPath := NormalizePath(header.Name)
-		destinationPath = filepath.Join(destination, destinationPath)
-		isDirectory := header.Typeflag == tar.TypeDir
-		isRegularFile := header.Typeflag == tar.TypeReg
+	return nil
+}
 
-		// Unknown content type, just ignore
-		if !isDirectory && !isRegularFile {
-			continue
-		}
+// Extract single item of zip file
+func extractZipFile(extractor *extractor, file *zip.File) (err error) {
 
-		// If is a directory, just ensure that the folder exists
-		if isDirectory {
-			err = os.MkdirAll(destinationPath, 0755)
-			if err != nil {
-				return err
-			}
+	mode := file.Mode()
+	name := strings.ReplaceAll(file.Name, "\\", "/")
+	isDirectory := mode.IsDir() || strings.HasSuffix(name, "/")
 
-			continue
-		}
+	// If is a directory, just ensure that the folder exists
+	if isDirectory {
+		return extractor.Directory(file.Name)
+	}
+
+	// Read file content
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
 
-		// If is a regular file, make sure that the parent folder exists
-		err = os.MkdirAll(filepath.Dir(destinationPath), 0755)
+	// Content of symbolic links is the link target
+	if mode&os.ModeSymlink != 0 {
+		target, err := io.ReadAll(io.LimitReader(reader, 4096))
 		if err != nil {
 			return err
 		}
 
-		// Now create or replace the target file
-		flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
-		fileMode := os.FileMode(header.Mode)
-		fileWriter, err := os.OpenFile(destinationPath, flags, fileMode)
-		if err != nil {
+		return extractor.Symlink(file.Name, string(target))
+	}
+
+	return extractor.File(file.Name, mode, reader)
+}
+
+// Extract tar stream content into destination
+func extractTar(reader io.Reader, destination string) (err error) {
+
+	extractor, err := newExtractor(destination)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errors.Join(err, extractor.Close())
+	}()
+
+	// Process each item of the archive
+	// Files outside of the archive will not be removed
+	tarReader := tar.NewReader(reader)
+	for {
+
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break // End of archive
+		} else if err != nil {
 			return err
 		}
 
-		defer func() {
-			errors.Join(err, fileWriter.Close())
-		}()
+		mode := os.FileMode(header.Mode).Perm()
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = extractor.Directory(header.Name)
+		case tar.TypeReg:
+			err = extractor.File(header.Name, mode, tarReader)
+		case tar.TypeSymlink:
+			err = extractor.Symlink(header.Name, header.Linkname)
+		case tar.TypeLink:
+			err = extractor.Hardlink(header.Name, header.Linkname, mode)
+		default:
+			// Unknown content type, just ignore
+			cli.Debug("Skipping unsupported entry %s\n", header.Name)
+		}
 
-		// Copy file content to target
-		_, err = io.Copy(fileWriter, tarReader)
 		if err != nil {
 			return err
 		}
@@ -193,11 +405,47 @@ func ExtractTarGz(source string, destination string) error {
 	return nil
 }
 
+// Extract given source .tar.gz file content into destination
+func ExtractTarGz(source string, destination string) (err error) {
+
+	cli.Debug("Extracting %s to %s\n", source, destination)
+
+	// Open the archive file
+	archive, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errors.Join(err, archive.Close())
+	}()
+
+	// Create the gzip reader for archive
+	gzipReader, err := gzip.NewReader(archive)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errors.Join(err, gzipReader.Close())
+	}()
+
+	return extractTar(gzipReader, destination)
+}
+
 // Extract given source .tar.xz file content into destination
 func ExtractTarXz(source string, destination string) error {
 
 	cli.Debug("Extracting %s to %s\n", source, destination)
 
+	err := extractTarXz(source, destination)
+	if !errors.Is(err, lzma.ErrUnsupported) {
+		return err
+	}
+
+	// Fallback to system tar for unsupported filters
+	cli.Debug("Using system tar to extract %s: %s\n", source, err)
+
 	// Create script to perform operation
 	script := fmt.Sprintf(
 		`tar -xf "%s" -C "%s"`,
@@ -207,7 +455,7 @@ func ExtractTarXz(source string, destination string) error {
 
 	// Run extraction process
 	command := cli.Command(script)
-	err := cli.Run(command)
+	err = cli.Run(command)
 	if err != nil {
 		return err
 	}
@@ -215,11 +463,41 @@ func ExtractTarXz(source string, destination string) error {
 	return nil
 }
 
+// Extract .tar.xz file content with native XZ reader
+func extractTarXz(source string, destination string) (err error) {
+
+	// Open the archive file
+	archive, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errors.Join(err, archive.Close())
+	}()
+
+	// Create the xz reader for archive
+	xzReader, err := lzma.NewXZReader(archive)
+	if err != nil {
+		return err
+	}
+
+	return extractTar(xzReader, destination)
+}
+
 // Extract given source .7z file content into destination
 func Extract7z(source string, destination string) error {
 
 	cli.Debug("Extracting %s to %s\n", source, destination)
 
+	err := extract7z(source, destination)
+	if !errors.Is(err, sevenzip.ErrUnsupported) && !errors.Is(err, lzma.ErrUnsupported) {
+		return err
+	}
+
+	// Fallback to 7-Zip program for unsupported methods
+	cli.Debug("Using 7-Zip program to extract %s: %s\n", source, err)
+
 	// Create script to perform operation
 	script := ""
 	if cli.IsLinux() {
@@ -248,10 +526,55 @@ func Extract7z(source string, destination string) error {
 
 	// Run extraction process
 	command := cli.Command(script)
-	err := cli.Run(command)
+	err = cli.Run(command)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// Extract .7z file content with native 7z reader
+func extract7z(source string, destination string) (err error) {
+
+	// Open 7z file to see its content
+	archive, err := sevenzip.Open(source)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errors.Join(err, archive.Close())
+	}()
+
+	extractor, err := newExtractor(destination)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errors.Join(err, extractor.Close())
+	}()
+
+	// Process each item of the archive
+	// Files outside of the archive will not be removed
+	return archive.Walk(func(file *sevenzip.File, content io.Reader) error {
+
+		mode := file.Mode()
+		if mode.IsDir() {
+			return extractor.Directory(file.Name)
+		}
+
+		// Content of symbolic links is the link target
+		if mode&os.ModeSymlink != 0 {
+			target, err := io.ReadAll(io.LimitReader(content, 4096))
+			if err != nil {
+				return err
+			}
+
+			return extractor.Symlink(file.Name, string(target))
+		}
+
+		return extractor.File(file.Name, mode, content)
+	})
+}
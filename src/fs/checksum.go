@@ -0,0 +1,37 @@
+package fs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"os"
+)
+
+// Calculate the checksum of file at given path with the given hash algorithm
+func HashFile(path string, algorithm hash.Hash) (string, error) {
+
+	// Open file for reading
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		errors.Join(err, file.Close())
+	}()
+
+	// Stream file content into hash
+	_, err = io.Copy(algorithm, file)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(algorithm.Sum(nil)), nil
+}
+
+// Calculate the SHA-256 checksum of file at given path
+func Checksum(path string) (string, error) {
+	return HashFile(path, sha256.New())
+}
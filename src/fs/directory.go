@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/mateussouzaweb/nicedeck/src/cli"
 )
@@ -58,9 +59,96 @@ func RemoveDirectory(path string) error {
 	return nil
 }
 
+// Move directory to destination with rename
+// Destination should not exist to avoid merging the content
+func MoveDirectory(source string, destination string) error {
+
+	// Check if directory exist
+	exist, err := DirectoryExist(source)
+	if err != nil {
+		return err
+	} else if !exist {
+		return nil
+	}
+
+	cli.Debug("Moving directory %s to %s\n", source, destination)
+
+	// Ensure that destination parent folder exists
+	err = os.MkdirAll(filepath.Dir(destination), 0774)
+	if err != nil {
+		return err
+	}
+
+	// Move directory using rename command
+	err = os.Rename(source, destination)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // Copy directory content to destination
 // When content already exists, it will be replaced
 func CopyDirectory(source string, destination string) error {
+	return copyDirectory(source, destination, "")
+}
+
+// Copy directory content to destination, including symbolic links
+// Only links resolving inside the source directory are copied, so these
+// links keep pointing to the copied content
+func CopyDirectoryWithLinks(source string, destination string) error {
+
+	root, err := filepath.EvalSymlinks(source)
+	if err != nil {
+		return err
+	}
+
+	return copyDirectory(source, destination, root)
+}
+
+// Copy symbolic link to destination when it resolves inside root
+func copySymlink(source string, destination string, root string) error {
+
+	real, err := filepath.EvalSymlinks(source)
+	if err != nil {
+		cli.Debug("Skipping broken symbolic link %s: %s\n", source, err)
+		return nil
+	}
+
+	relative, err := filepath.Rel(root, real)
+	if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(os.PathSeparator)) {
+		cli.Debug("Skipping symbolic link outside of %s: %s\n", root, source)
+		return nil
+	}
+
+	target, err := os.Readlink(source)
+	if err != nil {
+		return err
+	}
+
+	// Replace existing file or link, but never a directory
+	info, err := os.Lstat(destination)
+	if err == nil && !info.IsDir() {
+		err = os.Remove(destination)
+		if err != nil {
+			return err
+		}
+	} else if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	err = os.Symlink(target, destination)
+	if err != nil && cli.IsWindows() {
+		cli.Debug("Skipping symbolic link %s: %s\n", source, err)
+		return nil
+	}
+
+	return err
+}
+
+// Copy directory content, including links inside root when root is given
+func copyDirectory(source string, destination string, root string) error {
 
 	// Read stat from source path
 	stat, err := os.Stat(source)
@@ -85,13 +173,13 @@ func CopyDirectory(source string, destination string) error {
 		return err
 	}
 
-	// Process list of entries, but skip symbolic links
+	// Process list of entries, symbolic links are skipped without root
 	for _, entry := range entries {
 		sourcePath := filepath.Join(source, entry.Name())
 		destinationPath := filepath.Join(destination, entry.Name())
 
 		if entry.IsDir() {
-			err = CopyDirectory(sourcePath, destinationPath)
+			err = copyDirectory(sourcePath, destinationPath, root)
 			if err != nil {
 				return err
 			}
@@ -100,6 +188,11 @@ func CopyDirectory(source string, destination string) error {
 			if err != nil {
 				return err
 			}
+		} else if entry.Type()&os.ModeSymlink != 0 && root != "" {
+			err = copySymlink(sourcePath, destinationPath, root)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
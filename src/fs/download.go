@@ -0,0 +1,314 @@
+package fs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+)
+
+// Download struct
+// Download represents the progress of a file transfer
+// Status is one of downloading, completed or failed
+type Download struct {
+	URL         string `json:"url"`
+	Destination string `json:"destination"`
+	Downloaded  int64  `json:"downloaded"`
+	Total       int64  `json:"total"`
+	Status      string `json:"status"`
+	Error       string `json:"error"`
+}
+
+// Shared state of the download manager
+// Fields of active downloads are only changed under the lock
+var manager = struct {
+	sync.Mutex
+	active []*Download
+}{}
+
+// HTTP client for downloads
+// Only connection phases have timeouts, transfer is watched for stalls
+var downloadClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout:   30 * time.Second,
+		ResponseHeaderTimeout: 60 * time.Second,
+	},
+}
+
+// Time without receiving data before aborting the transfer
+const stallTimeout = 60 * time.Second
+
+// Number of attempts to complete a download
+const downloadAttempts = 3
+
+// Retrieve snapshot of active downloads
+func GetDownloads() []Download {
+	manager.Lock()
+	defer manager.Unlock()
+
+	result := []Download{}
+	for _, item := range manager.active {
+		result = append(result, *item)
+	}
+
+	return result
+}
+
+// Update status of download and the list of active downloads
+func (d *Download) finish(status string, err error) {
+	manager.Lock()
+	defer manager.Unlock()
+
+	d.Status = status
+	if err != nil {
+		d.Error = err.Error()
+	}
+
+	manager.active = slices.DeleteFunc(manager.active, func(item *Download) bool {
+		return item == d
+	})
+}
+
+// Update transferred bytes of download under the manager lock
+func (d *Download) update(downloaded int64, total int64) {
+	manager.Lock()
+	defer manager.Unlock()
+	d.Downloaded = downloaded
+	d.Total = total
+}
+
+// Reader that reports progress and watches for stalled transfers
+type progressReader struct {
+	reader   io.Reader
+	download *Download
+	timer    *time.Timer
+	step     int64
+}
+
+// Read content while updating progress
+func (p *progressReader) Read(buffer []byte) (int, error) {
+	read, err := p.reader.Read(buffer)
+	if read > 0 {
+		p.timer.Reset(stallTimeout)
+		p.download.update(p.download.Downloaded+int64(read), p.download.Total)
+
+		// Print progress on console for each quarter of the file
+		if p.download.Total > 0 {
+			step := p.download.Downloaded * 4 / p.download.Total
+			if step > p.step {
+				p.step = step
+				cli.Printf(cli.ColorDefault, "Downloaded %d%% of %s (%.1f MB)\n",
+					step*25,
+					strings.TrimSuffix(filepath.Base(p.download.Destination), ".tmp"),
+					float64(p.download.Total)/1024/1024,
+				)
+			}
+		}
+	}
+
+	return read, err
+}
+
+// Retrieve path of file with resume information of the download
+func resumePath(destination string) string {
+	return destination + ".resume"
+}
+
+// Read resume information of partial download
+// Information contains the URL and the validator of the remote file
+func readResume(destination string) (string, string) {
+	content, err := os.ReadFile(resumePath(destination))
+	if err != nil {
+		return "", ""
+	}
+
+	lines := strings.SplitN(string(content), "\n", 2)
+	if len(lines) != 2 {
+		return "", ""
+	}
+
+	return lines[0], strings.TrimSpace(lines[1])
+}
+
+// Transfer content from URL to destination
+// Partial content is appended when the remote file did not change
+func transfer(download *Download) error {
+
+	destination := download.Destination
+	resumeURL, validator := readResume(destination)
+
+	var offset int64
+	if info, err := os.Stat(destination); err == nil && resumeURL == download.URL && validator != "" {
+		offset = info.Size()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, download.URL, nil)
+	if err != nil {
+		return err
+	}
+
+	// Validator makes server return full content when file changed
+	if offset > 0 {
+		request.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		request.Header.Set("If-Range", validator)
+	}
+
+	response, err := downloadClient.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		errors.Join(err, response.Body.Close())
+	}()
+
+	// Partial content already complete on destination
+	if response.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		contentRange := response.Header.Get("Content-Range")
+		total, _ := strconv.ParseInt(strings.TrimPrefix(contentRange, "bytes */"), 10, 64)
+		if total == offset {
+			download.update(offset, total)
+			return nil
+		}
+
+		// Restart from the beginning on the next attempt
+		return errors.Join(
+			fmt.Errorf("could not resume download %s: %s", download.URL, response.Status),
+			RemoveFile(destination),
+			RemoveFile(resumePath(destination)),
+		)
+	}
+
+	// Reject error responses to avoid saving error pages as the file
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("could not download %s: %s", download.URL, response.Status)
+	}
+
+	// Ensure that destination folder exists
+	err = os.MkdirAll(filepath.Dir(destination), 0774)
+	if err != nil {
+		return err
+	}
+
+	// Append on partial content or start again otherwise
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	download.update(0, response.ContentLength)
+	if response.StatusCode == http.StatusPartialContent {
+		flags = os.O_WRONLY | os.O_APPEND
+		total := int64(-1)
+		if response.ContentLength >= 0 {
+			total = offset + response.ContentLength
+		}
+		download.update(offset, total)
+		cli.Debug("Resuming download of %s at %d bytes\n", download.URL, offset)
+	}
+
+	// Save validator to allow resuming on the next attempt
+	validator = response.Header.Get("ETag")
+	if validator == "" {
+		validator = response.Header.Get("Last-Modified")
+	}
+	err = WriteFile(resumePath(destination), download.URL+"\n"+validator)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(destination, flags, 0666)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		errors.Join(err, file.Close())
+	}()
+
+	// Abort transfer when no data is received for a while
+	timer := time.AfterFunc(stallTimeout, cancel)
+	defer timer.Stop()
+
+	reader := &progressReader{
+		reader:   response.Body,
+		download: download,
+		timer:    timer,
+	}
+	if download.Total > 0 {
+		reader.step = download.Downloaded * 4 / download.Total
+	}
+
+	_, err = io.Copy(file, reader)
+	if err != nil {
+		return err
+	}
+
+	if download.Total > 0 && download.Downloaded != download.Total {
+		return fmt.Errorf("incomplete download %s: %d of %d bytes", download.URL, download.Downloaded, download.Total)
+	}
+
+	return nil
+}
+
+// Download file from URL into destination with resume support
+// Partial files from failed attempts are resumed on the next call
+func ResumeDownload(url string, destination string) error {
+
+	download := &Download{
+		URL:         url,
+		Destination: destination,
+		Status:      "downloading",
+	}
+
+	manager.Lock()
+	manager.active = append(manager.active, download)
+	manager.Unlock()
+
+	var err error
+	for attempt := 1; attempt <= downloadAttempts; attempt++ {
+		err = transfer(download)
+		if err == nil {
+			break
+		}
+
+		cli.Debug("Download attempt %d of %s failed: %s\n", attempt, url, err)
+	}
+
+	if err != nil {
+		download.finish("failed", err)
+	} else {
+		download.finish("completed", nil)
+	}
+
+	return err
+}
+
+// Remove resume information once downloaded content was consumed
+// Until then, new calls to the same download complete instantly
+func FinishDownload(destination string) error {
+	return RemoveFile(resumePath(destination))
+}
+
+// Move partial download with resume information to another location
+func MoveDownload(source string, destination string) error {
+	return errors.Join(
+		MoveFile(source, destination),
+		MoveFile(resumePath(source), resumePath(destination)),
+	)
+}
@@ -2,6 +2,7 @@ package fs
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
@@ -189,6 +190,11 @@ func DownloadFile(url string, destination string, overwriteExisting bool) error
 		errors.Join(err, response.Body.Close())
 	}()
 
+	// Reject error responses to avoid saving error pages as the file
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("could not download %s: %s", url, response.Status)
+	}
+
 	// Ensure that destination folder exists
 	err = os.MkdirAll(filepath.Dir(destination), 0774)
 	if err != nil {
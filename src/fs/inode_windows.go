@@ -0,0 +1,11 @@
+package fs
+
+import (
+	"os"
+)
+
+// Retrieve inode number of file, zero when not available
+// File information on Windows does not include the file index
+func Inode(info os.FileInfo) uint64 {
+	return 0
+}
@@ -0,0 +1,549 @@
+package lzma
+
+import (
+	"errors"
+	"io"
+)
+
+// Constants of the LZMA specification
+const (
+	numStates          = 12
+	numPosBitsMax      = 4
+	numLenToPosStates  = 4
+	numAlignBits       = 4
+	startPosModelIndex = 4
+	endPosModelIndex   = 14
+	numFullDistances   = 1 << (endPosModelIndex >> 1)
+	matchMinLen        = 2
+	probabilityInit    = 1024
+)
+
+// Errors of the decoder
+var (
+	ErrCorrupted   = errors.New("lzma: corrupted data")
+	ErrProperties  = errors.New("lzma: invalid properties")
+	ErrUnsupported = errors.New("lzma: unsupported format")
+)
+
+// Range decoder of compressed stream
+type rangeDecoder struct {
+	reader io.ByteReader
+	rng    uint32
+	code   uint32
+	err    error
+}
+
+// Read next byte and keep the first error
+func (r *rangeDecoder) readByte() byte {
+	value, err := r.reader.ReadByte()
+	if err != nil && r.err == nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		r.err = err
+	}
+	return value
+}
+
+// Initialize range decoder from the first bytes of the stream
+func (r *rangeDecoder) init(reader io.ByteReader) error {
+	r.reader = reader
+	r.rng = 0xFFFFFFFF
+	r.code = 0
+	r.err = nil
+
+	if r.readByte() != 0 {
+		return ErrCorrupted
+	}
+	for range 4 {
+		r.code = r.code<<8 | uint32(r.readByte())
+	}
+
+	return r.err
+}
+
+// Normalize range when it is too small
+func (r *rangeDecoder) normalize() {
+	if r.rng < 1<<24 {
+		r.rng <<= 8
+		r.code = r.code<<8 | uint32(r.readByte())
+	}
+}
+
+// Decode bit with adaptive probability
+func (r *rangeDecoder) bit(probability *uint16) uint32 {
+	bound := (r.rng >> 11) * uint32(*probability)
+	var result uint32
+	if r.code < bound {
+		r.rng = bound
+		*probability += (2048 - *probability) >> 5
+	} else {
+		r.rng -= bound
+		r.code -= bound
+		*probability -= *probability >> 5
+		result = 1
+	}
+	r.normalize()
+	return result
+}
+
+// Decode bits with fixed probability
+func (r *rangeDecoder) direct(count int) uint32 {
+	var result uint32
+	for range count {
+		r.rng >>= 1
+		r.code -= r.rng
+		t := 0 - (r.code >> 31)
+		r.code += r.rng & t
+		r.normalize()
+		result = result<<1 + t + 1
+	}
+	return result
+}
+
+// Decode symbol from bit tree
+func (r *rangeDecoder) tree(probabilities []uint16, bits int) uint32 {
+	m := uint32(1)
+	for range bits {
+		m = m<<1 + r.bit(&probabilities[m])
+	}
+	return m - 1<<bits
+}
+
+// Decode symbol from reverse bit tree
+func (r *rangeDecoder) reverseTree(probabilities []uint16, bits int) uint32 {
+	m := uint32(1)
+	var symbol uint32
+	for i := range bits {
+		bit := r.bit(&probabilities[m])
+		m = m<<1 + bit
+		symbol |= bit << i
+	}
+	return symbol
+}
+
+// Sliding window with decoded content
+// Content is kept until read, then the window wraps to the beginning
+type window struct {
+	buffer  []byte
+	pos     int
+	unread  int
+	history int
+	total   int64
+}
+
+// Create window for dictionary size
+func newWindow(size int) *window {
+	if size < 4096 {
+		size = 4096
+	}
+	return &window{buffer: make([]byte, size)}
+}
+
+// Reset dictionary to avoid references to previous content
+func (w *window) reset() {
+	w.history = 0
+	w.total = 0
+}
+
+// Available space for new content
+// Window wraps when all content was already read
+func (w *window) space() int {
+	if w.pos == len(w.buffer) && w.unread == w.pos {
+		w.pos = 0
+		w.unread = 0
+	}
+	return len(w.buffer) - w.pos
+}
+
+// Check if there is content to read
+func (w *window) pending() bool {
+	return w.unread < w.pos
+}
+
+// Read decoded content
+func (w *window) read(p []byte) int {
+	n := copy(p, w.buffer[w.unread:w.pos])
+	w.unread += n
+	return n
+}
+
+// Put byte on window
+func (w *window) put(value byte) {
+	w.buffer[w.pos] = value
+	w.pos++
+	w.total++
+	if w.history < len(w.buffer) {
+		w.history++
+	}
+}
+
+// Retrieve byte at given distance, where 1 is the last byte
+func (w *window) get(distance int) byte {
+	index := w.pos - distance
+	if index < 0 {
+		index += len(w.buffer)
+	}
+	return w.buffer[index]
+}
+
+// Length decoder
+type lengthDecoder struct {
+	choice  uint16
+	choice2 uint16
+	low     [1 << numPosBitsMax][1 << 3]uint16
+	mid     [1 << numPosBitsMax][1 << 3]uint16
+	high    [1 << 8]uint16
+}
+
+// Reset probabilities of length decoder
+func (l *lengthDecoder) reset() {
+	l.choice = probabilityInit
+	l.choice2 = probabilityInit
+	for i := range l.low {
+		for j := range l.low[i] {
+			l.low[i][j] = probabilityInit
+			l.mid[i][j] = probabilityInit
+		}
+	}
+	for i := range l.high {
+		l.high[i] = probabilityInit
+	}
+}
+
+// Decode length for position state
+func (l *lengthDecoder) decode(r *rangeDecoder, posState uint32) uint32 {
+	if r.bit(&l.choice) == 0 {
+		return r.tree(l.low[posState][:], 3)
+	}
+	if r.bit(&l.choice2) == 0 {
+		return 8 + r.tree(l.mid[posState][:], 3)
+	}
+	return 16 + r.tree(l.high[:], 8)
+}
+
+// Properties of LZMA stream
+type properties struct {
+	lc int
+	lp int
+	pb int
+}
+
+// Parse properties byte
+func parseProperties(value byte) (properties, error) {
+	if value >= 9*5*5 {
+		return properties{}, ErrProperties
+	}
+
+	d := int(value)
+	result := properties{}
+	result.lc = d % 9
+	d /= 9
+	result.lp = d % 5
+	result.pb = d / 5
+
+	return result, nil
+}
+
+// LZMA decoder state
+type decoder struct {
+	props      properties
+	rc         rangeDecoder
+	window     *window
+	literals   []uint16
+	isMatch    [numStates << numPosBitsMax]uint16
+	isRep      [numStates]uint16
+	isRepG0    [numStates]uint16
+	isRepG1    [numStates]uint16
+	isRepG2    [numStates]uint16
+	isRep0Long [numStates << numPosBitsMax]uint16
+	posSlot    [numLenToPosStates][1 << 6]uint16
+	posSpecial [1 + numFullDistances - endPosModelIndex]uint16
+	align      [1 << numAlignBits]uint16
+	length     lengthDecoder
+	repLength  lengthDecoder
+	state      uint32
+	rep        [4]uint32
+	pending    int
+	eos        bool
+}
+
+// Create decoder with properties over window
+func newDecoder(props properties, window *window) *decoder {
+	d := &decoder{window: window}
+	d.setProperties(props)
+	return d
+}
+
+// Update properties and reset the state
+func (d *decoder) setProperties(props properties) {
+	d.props = props
+	size := 0x300 << (props.lc + props.lp)
+	if len(d.literals) != size {
+		d.literals = make([]uint16, size)
+	}
+	d.reset()
+}
+
+// Reset probabilities and state
+func (d *decoder) reset() {
+	for i := range d.literals {
+		d.literals[i] = probabilityInit
+	}
+	for _, probabilities := range [][]uint16{
+		d.isMatch[:], d.isRep[:], d.isRepG0[:], d.isRepG1[:], d.isRepG2[:],
+		d.isRep0Long[:], d.posSpecial[:], d.align[:],
+	} {
+		for i := range probabilities {
+			probabilities[i] = probabilityInit
+		}
+	}
+	for i := range d.posSlot {
+		for j := range d.posSlot[i] {
+			d.posSlot[i][j] = probabilityInit
+		}
+	}
+	d.length.reset()
+	d.repLength.reset()
+	d.state = 0
+	d.rep = [4]uint32{}
+	d.pending = 0
+	d.eos = false
+}
+
+// Decode literal byte
+func (d *decoder) decodeLiteral() {
+	w := d.window
+	var previous uint32
+	if w.history > 0 {
+		previous = uint32(w.get(1))
+	}
+
+	position := uint32(w.total) & (1<<d.props.lp - 1)
+	state := position<<d.props.lc + previous>>(8-d.props.lc)
+	probabilities := d.literals[0x300*state : 0x300*state+0x300]
+
+	symbol := uint32(1)
+	if d.state >= 7 {
+		match := uint32(w.get(int(d.rep[0]) + 1))
+		for symbol < 0x100 {
+			matchBit := (match >> 7) & 1
+			match <<= 1
+			bit := d.rc.bit(&probabilities[(1+matchBit)<<8+symbol])
+			symbol = symbol<<1 | bit
+			if matchBit != bit {
+				break
+			}
+		}
+	}
+	for symbol < 0x100 {
+		symbol = symbol<<1 | d.rc.bit(&probabilities[symbol])
+	}
+
+	w.put(byte(symbol - 0x100))
+}
+
+// Decode distance of match
+func (d *decoder) decodeDistance(length uint32) uint32 {
+	lenState := min(length, numLenToPosStates-1)
+	slot := d.rc.tree(d.posSlot[lenState][:], 6)
+	if slot < startPosModelIndex {
+		return slot
+	}
+
+	bits := int(slot>>1) - 1
+	distance := (2 | slot&1) << bits
+	if slot < endPosModelIndex {
+		return distance + d.rc.reverseTree(d.posSpecial[distance-slot:], bits)
+	}
+
+	distance += d.rc.direct(bits-numAlignBits) << numAlignBits
+	return distance + d.rc.reverseTree(d.align[:], numAlignBits)
+}
+
+// Copy pending bytes of match until limit
+func (d *decoder) copyMatch(limit int) int {
+	count := min(d.pending, limit)
+	distance := int(d.rep[0]) + 1
+	for range count {
+		d.window.put(d.window.get(distance))
+	}
+	d.pending -= count
+	return count
+}
+
+// Decode content until limit is reached or stream ends
+// Limit must not exceed the available space on window
+func (d *decoder) decode(limit int) (int, error) {
+
+	w := d.window
+	produced := 0
+	pbMask := uint32(1)<<d.props.pb - 1
+
+	if d.pending > 0 {
+		produced += d.copyMatch(limit)
+	}
+
+	for produced < limit && !d.eos {
+
+		posState := uint32(w.total) & pbMask
+
+		// Literal
+		if d.rc.bit(&d.isMatch[d.state<<numPosBitsMax+posState]) == 0 {
+			d.decodeLiteral()
+			produced++
+
+			if d.state < 4 {
+				d.state = 0
+			} else if d.state < 10 {
+				d.state -= 3
+			} else {
+				d.state -= 6
+			}
+		} else {
+			var length uint32
+
+			if d.rc.bit(&d.isRep[d.state]) != 0 {
+				// Repeated match
+				if w.history == 0 {
+					return produced, ErrCorrupted
+				}
+
+				if d.rc.bit(&d.isRepG0[d.state]) == 0 {
+					if d.rc.bit(&d.isRep0Long[d.state<<numPosBitsMax+posState]) == 0 {
+						if d.state < 7 {
+							d.state = 9
+						} else {
+							d.state = 11
+						}
+						w.put(w.get(int(d.rep[0]) + 1))
+						produced++
+						continue
+					}
+				} else {
+					var distance uint32
+					if d.rc.bit(&d.isRepG1[d.state]) == 0 {
+						distance = d.rep[1]
+					} else {
+						if d.rc.bit(&d.isRepG2[d.state]) == 0 {
+							distance = d.rep[2]
+						} else {
+							distance = d.rep[3]
+							d.rep[3] = d.rep[2]
+						}
+						d.rep[2] = d.rep[1]
+					}
+					d.rep[1] = d.rep[0]
+					d.rep[0] = distance
+				}
+
+				length = d.repLength.decode(&d.rc, posState)
+				if d.state < 7 {
+					d.state = 8
+				} else {
+					d.state = 11
+				}
+			} else {
+				// Simple match
+				d.rep[3] = d.rep[2]
+				d.rep[2] = d.rep[1]
+				d.rep[1] = d.rep[0]
+				length = d.length.decode(&d.rc, posState)
+				if d.state < 7 {
+					d.state = 7
+				} else {
+					d.state = 10
+				}
+
+				d.rep[0] = d.decodeDistance(length)
+				if d.rep[0] == 0xFFFFFFFF {
+					d.eos = true
+					break
+				}
+				if int(d.rep[0]) >= w.history {
+					return produced, ErrCorrupted
+				}
+			}
+
+			d.pending = int(length + matchMinLen)
+			produced += d.copyMatch(limit - produced)
+		}
+
+		if d.rc.err != nil {
+			return produced, d.rc.err
+		}
+	}
+
+	return produced, d.rc.err
+}
+
+// Reader of LZMA stream as used on 7z archives
+type Reader struct {
+	decoder   *decoder
+	window    *window
+	remaining int64
+	err       error
+}
+
+// Create reader of LZMA stream with 5 bytes of properties
+// Size is the uncompressed size or -1 when stream has end marker
+func NewReader(reader io.Reader, header []byte, size int64) (*Reader, error) {
+
+	if len(header) < 5 {
+		return nil, ErrProperties
+	}
+
+	props, err := parseProperties(header[0])
+	if err != nil {
+		return nil, err
+	}
+
+	dictionary := int64(header[1]) | int64(header[2])<<8 | int64(header[3])<<16 | int64(header[4])<<24
+	if size >= 0 && size < dictionary {
+		dictionary = size
+	}
+
+	window := newWindow(int(dictionary))
+	result := &Reader{
+		decoder:   newDecoder(props, window),
+		window:    window,
+		remaining: size,
+	}
+
+	err = result.decoder.rc.init(byteReader(reader))
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Read decoded content
+func (r *Reader) Read(p []byte) (int, error) {
+	for {
+		if r.window.pending() {
+			return r.window.read(p), nil
+		}
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.remaining == 0 || r.decoder.eos {
+			return 0, io.EOF
+		}
+
+		limit := int64(r.window.space())
+		if r.remaining > 0 && r.remaining < limit {
+			limit = r.remaining
+		}
+
+		produced, err := r.decoder.decode(int(limit))
+		if r.remaining > 0 {
+			r.remaining -= int64(produced)
+		}
+		if err != nil {
+			r.err = err
+		} else if r.decoder.eos && r.remaining > 0 {
+			r.err = io.ErrUnexpectedEOF
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package lzma
+
+import (
+	"io"
+)
+
+// Filter converts content in place and returns the processed length
+// Remaining content is processed again with more data on next call
+type filter interface {
+	convert(buffer []byte, final bool) int
+}
+
+// Reader applying filter on content of another reader
+type filterReader struct {
+	reader io.Reader
+	filter filter
+	buffer []byte
+	start  int
+	ready  int
+	end    int
+	eof    bool
+	err    error
+}
+
+// Create reader applying filter over reader
+func newFilterReader(reader io.Reader, filter filter) *filterReader {
+	return &filterReader{
+		reader: reader,
+		filter: filter,
+		buffer: make([]byte, 1<<16),
+	}
+}
+
+// Read filtered content
+func (f *filterReader) Read(p []byte) (int, error) {
+	for {
+		if f.start < f.ready {
+			n := copy(p, f.buffer[f.start:f.ready])
+			f.start += n
+			return n, nil
+		}
+		if f.err != nil {
+			return 0, f.err
+		}
+		if f.eof {
+			return 0, io.EOF
+		}
+
+		// Move unprocessed content to the beginning
+		copy(f.buffer, f.buffer[f.start:f.end])
+		f.end -= f.start
+		f.start = 0
+
+		n, err := f.reader.Read(f.buffer[f.end:])
+		f.end += n
+		if err == io.EOF {
+			f.eof = true
+		} else if err != nil {
+			f.err = err
+		}
+
+		f.ready = f.filter.convert(f.buffer[:f.end], f.eof)
+		if f.eof {
+			f.ready = f.end
+		}
+	}
+}
+
+// Filter for x86 branch instructions
+type bcjFilter struct {
+	pos      uint32
+	prevMask uint32
+}
+
+// Allowed states and bit numbers of the x86 filter
+var (
+	bcjAllowed   = [8]bool{true, true, true, false, true, false, false, false}
+	bcjBitNumber = [8]uint32{0, 1, 2, 2, 3, 3, 3, 3}
+)
+
+// Create reader that decodes x86 branch conversion
+func NewBCJReader(reader io.Reader, start uint32) io.Reader {
+	return newFilterReader(reader, &bcjFilter{pos: start + 5})
+}
+
+// Check if byte is a possible most significant byte of address
+func bcjTest(value byte) bool {
+	return value == 0x00 || value == 0xFF
+}
+
+// Convert x86 relative addresses back into the original form
+func (b *bcjFilter) convert(buffer []byte, _ bool) int {
+
+	end := len(buffer) - 5
+	prevPos := -1
+	i := 0
+
+	for ; i <= end; i++ {
+		if buffer[i]&0xFE != 0xE8 {
+			continue
+		}
+
+		prevPos = i - prevPos
+		if prevPos&^3 != 0 {
+			b.prevMask = 0
+		} else {
+			b.prevMask = (b.prevMask << (prevPos - 1)) & 7
+			if b.prevMask != 0 {
+				if !bcjAllowed[b.prevMask] || bcjTest(buffer[i+4-int(bcjBitNumber[b.prevMask])]) {
+					prevPos = i
+					b.prevMask = b.prevMask<<1 | 1
+					continue
+				}
+			}
+		}
+
+		prevPos = i
+		if !bcjTest(buffer[i+4]) {
+			b.prevMask = b.prevMask<<1 | 1
+			continue
+		}
+
+		source := uint32(buffer[i+1]) | uint32(buffer[i+2])<<8 | uint32(buffer[i+3])<<16 | uint32(buffer[i+4])<<24
+		var destination uint32
+		for {
+			destination = source - (b.pos + uint32(i))
+			if b.prevMask == 0 {
+				break
+			}
+
+			index := bcjBitNumber[b.prevMask] * 8
+			if !bcjTest(byte(destination >> (24 - index))) {
+				break
+			}
+
+			source = destination ^ (1<<(32-index) - 1)
+		}
+
+		buffer[i+1] = byte(destination)
+		buffer[i+2] = byte(destination >> 8)
+		buffer[i+3] = byte(destination >> 16)
+		buffer[i+4] = ^byte((destination>>24)&1 - 1)
+		i += 4
+	}
+
+	prevPos = i - prevPos
+	if prevPos&^3 != 0 {
+		b.prevMask = 0
+	} else {
+		b.prevMask <<= prevPos - 1
+	}
+
+	b.pos += uint32(i)
+	return i
+}
+
+// Filter for delta encoded content
+type deltaFilter struct {
+	distance int
+	history  [256]byte
+	pos      byte
+}
+
+// Create reader that decodes delta encoded content
+func NewDeltaReader(reader io.Reader, distance int) io.Reader {
+	return newFilterReader(reader, &deltaFilter{distance: distance})
+}
+
+// Restore original bytes by adding the byte at distance
+func (d *deltaFilter) convert(buffer []byte, _ bool) int {
+	for i := range buffer {
+		buffer[i] += d.history[d.pos-byte(d.distance)]
+		d.history[d.pos] = buffer[i]
+		d.pos++
+	}
+	return len(buffer)
+}
@@ -0,0 +1,198 @@
+package lzma
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// Convert reader into byte reader when needed
+func byteReader(reader io.Reader) io.ByteReader {
+	if result, ok := reader.(io.ByteReader); ok {
+		return result
+	}
+	return bufio.NewReader(reader)
+}
+
+// Retrieve dictionary size from LZMA2 properties byte
+func DictionarySize(value byte) (int64, error) {
+	bits := int64(value & 0x3F)
+	if bits > 40 {
+		return 0, ErrProperties
+	} else if bits == 40 {
+		return 0xFFFFFFFF, nil
+	}
+
+	return (2 | bits&1) << (bits/2 + 11), nil
+}
+
+// Reader of LZMA2 stream as used on XZ and 7z archives
+type Reader2 struct {
+	reader     io.Reader
+	window     *window
+	decoder    *decoder
+	chunk      []byte
+	remaining  int
+	compressed bool
+	needDict   bool
+	needProps  bool
+	finished   bool
+	err        error
+}
+
+// Create reader of LZMA2 stream with the properties byte
+// Size is the uncompressed size when known, or -1 otherwise
+func NewReader2(reader io.Reader, value byte, size int64) (*Reader2, error) {
+
+	dictionary, err := DictionarySize(value)
+	if err != nil {
+		return nil, err
+	}
+	if size >= 0 && size < dictionary {
+		dictionary = size
+	}
+
+	result := &Reader2{
+		reader:    reader,
+		window:    newWindow(int(dictionary)),
+		needDict:  true,
+		needProps: true,
+	}
+
+	return result, nil
+}
+
+// Read header of next chunk
+func (r *Reader2) nextChunk() error {
+
+	header := make([]byte, 1, 6)
+	_, err := io.ReadFull(r.reader, header)
+	if err != nil {
+		return io.ErrUnexpectedEOF
+	}
+
+	control := header[0]
+	if control == 0x00 {
+		r.finished = true
+		return nil
+	}
+
+	// Dictionary reset is required on first chunk
+	if control >= 0xE0 || control == 0x01 {
+		r.needProps = true
+		r.needDict = false
+		r.window.reset()
+	} else if r.needDict {
+		return ErrCorrupted
+	}
+
+	// Uncompressed chunk
+	if control < 0x80 {
+		if control > 0x02 {
+			return ErrCorrupted
+		}
+
+		header = header[:3]
+		_, err = io.ReadFull(r.reader, header[1:])
+		if err != nil {
+			return io.ErrUnexpectedEOF
+		}
+
+		r.compressed = false
+		r.remaining = int(header[1])<<8 | int(header[2]) + 1
+		return nil
+	}
+
+	// Compressed chunk with reset of state and properties when requested
+	header = header[:5]
+	_, err = io.ReadFull(r.reader, header[1:])
+	if err != nil {
+		return io.ErrUnexpectedEOF
+	}
+
+	r.compressed = true
+	r.remaining = int(control&0x1F)<<16 | int(header[1])<<8 | int(header[2]) + 1
+	packed := int(header[3])<<8 | int(header[4]) + 1
+
+	if control >= 0xC0 {
+		value := []byte{0}
+		_, err = io.ReadFull(r.reader, value)
+		if err != nil {
+			return io.ErrUnexpectedEOF
+		}
+
+		props, err := parseProperties(value[0])
+		if err != nil {
+			return err
+		} else if props.lc+props.lp > 4 {
+			return ErrProperties
+		}
+
+		r.needProps = false
+		if r.decoder == nil {
+			r.decoder = newDecoder(props, r.window)
+		} else {
+			r.decoder.setProperties(props)
+		}
+	} else if r.needProps {
+		return ErrCorrupted
+	} else if control >= 0xA0 {
+		r.decoder.reset()
+	}
+
+	// Each chunk starts a new range decoder over the packed content
+	if cap(r.chunk) < packed {
+		r.chunk = make([]byte, packed)
+	}
+	r.chunk = r.chunk[:packed]
+	_, err = io.ReadFull(r.reader, r.chunk)
+	if err != nil {
+		return io.ErrUnexpectedEOF
+	}
+
+	return r.decoder.rc.init(bytes.NewReader(r.chunk))
+}
+
+// Read decoded content
+func (r *Reader2) Read(p []byte) (int, error) {
+	for {
+		if r.window.pending() {
+			return r.window.read(p), nil
+		}
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.finished {
+			return 0, io.EOF
+		}
+
+		if r.remaining == 0 {
+			r.err = r.nextChunk()
+			continue
+		}
+
+		limit := min(r.window.space(), r.remaining)
+		if r.compressed {
+			produced, err := r.decoder.decode(limit)
+			r.remaining -= produced
+			if err != nil {
+				r.err = err
+			} else if r.decoder.eos {
+				r.err = ErrCorrupted
+			}
+			continue
+		}
+
+		// Uncompressed content is copied into the window
+		buffer := r.window.buffer[r.window.pos : r.window.pos+limit]
+		_, err := io.ReadFull(r.reader, buffer)
+		if err != nil {
+			r.err = io.ErrUnexpectedEOF
+			continue
+		}
+		for _, value := range buffer {
+			r.window.put(value)
+		}
+		r.remaining -= limit
+	}
+}
@@ -0,0 +1,392 @@
+package lzma
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"hash/crc32"
+	"hash/crc64"
+	"io"
+)
+
+// Magic bytes of XZ stream header and footer
+var (
+	xzHeaderMagic = []byte{0xFD, '7', 'z', 'X', 'Z', 0x00}
+	xzFooterMagic = []byte{'Y', 'Z'}
+)
+
+// Filter identifiers of XZ blocks
+const (
+	xzFilterDelta = 0x03
+	xzFilterX86   = 0x04
+	xzFilterLZMA2 = 0x21
+)
+
+// Errors of the XZ reader
+var ErrChecksum = errors.New("xz: checksum mismatch")
+
+// Table for CRC64 checks
+var crc64Table = crc64.MakeTable(crc64.ECMA)
+
+// Reader with count of consumed bytes
+type countingReader struct {
+	reader *bufio.Reader
+	count  int64
+}
+
+// Read content while counting bytes
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.count += int64(n)
+	return n, err
+}
+
+// Read byte while counting it
+func (c *countingReader) ReadByte() (byte, error) {
+	value, err := c.reader.ReadByte()
+	if err == nil {
+		c.count++
+	}
+	return value, err
+}
+
+// Read variable length integer
+func readVarint(reader io.ByteReader) (uint64, error) {
+	var result uint64
+	for i := 0; i < 9; i++ {
+		value, err := reader.ReadByte()
+		if err != nil {
+			return 0, io.ErrUnexpectedEOF
+		}
+
+		result |= uint64(value&0x7F) << (7 * i)
+		if value&0x80 == 0 {
+			return result, nil
+		}
+	}
+
+	return 0, ErrCorrupted
+}
+
+// Reader of XZ compressed stream
+type XZReader struct {
+	reader   *countingReader
+	check    byte
+	block    io.Reader
+	hash     hash.Hash
+	started  int64
+	blocks   int
+	finished bool
+	err      error
+}
+
+// Create reader of XZ compressed stream
+func NewXZReader(reader io.Reader) (*XZReader, error) {
+
+	result := &XZReader{
+		reader: &countingReader{reader: bufio.NewReader(reader)},
+	}
+
+	err := result.readStreamHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Retrieve size of check field
+func checkSize(check byte) int {
+	switch {
+	case check == 0:
+		return 0
+	case check <= 3:
+		return 4
+	case check <= 6:
+		return 8
+	case check <= 9:
+		return 16
+	case check <= 12:
+		return 32
+	}
+	return 64
+}
+
+// Read and validate stream header
+func (x *XZReader) readStreamHeader() error {
+
+	header := make([]byte, 12)
+	_, err := io.ReadFull(x.reader, header)
+	if err != nil {
+		return io.ErrUnexpectedEOF
+	}
+
+	if !bytes.Equal(header[:6], xzHeaderMagic) {
+		return ErrUnsupported
+	}
+	if crc32.ChecksumIEEE(header[6:8]) != binary.LittleEndian.Uint32(header[8:]) {
+		return ErrCorrupted
+	}
+	if header[6] != 0 || header[7] > 0x0F {
+		return ErrUnsupported
+	}
+
+	x.check = header[7]
+	x.blocks = 0
+	return nil
+}
+
+// Create hash for check type, where nil means the check is skipped
+func (x *XZReader) newHash() hash.Hash {
+	switch x.check {
+	case 0x01:
+		return crc32.NewIEEE()
+	case 0x04:
+		return crc64.New(crc64Table)
+	case 0x0A:
+		return sha256.New()
+	}
+	return nil
+}
+
+// Read block header and create reader of block content
+func (x *XZReader) readBlockHeader(first byte) error {
+
+	size := (int(first) + 1) * 4
+	header := make([]byte, size)
+	header[0] = first
+	_, err := io.ReadFull(x.reader, header[1:])
+	if err != nil {
+		return io.ErrUnexpectedEOF
+	}
+
+	if crc32.ChecksumIEEE(header[:size-4]) != binary.LittleEndian.Uint32(header[size-4:]) {
+		return ErrCorrupted
+	}
+
+	flags := header[1]
+	if flags&0x3C != 0 {
+		return ErrUnsupported
+	}
+
+	fields := bytes.NewReader(header[2 : size-4])
+	uncompressed := int64(-1)
+
+	if flags&0x40 != 0 {
+		_, err := readVarint(fields)
+		if err != nil {
+			return err
+		}
+	}
+	if flags&0x80 != 0 {
+		value, err := readVarint(fields)
+		if err != nil {
+			return err
+		}
+		uncompressed = int64(value)
+	}
+
+	// Filters are applied in reverse order when decoding
+	type filterFlags struct {
+		id    uint64
+		props []byte
+	}
+
+	filters := []filterFlags{}
+	for range int(flags&0x03) + 1 {
+		id, err := readVarint(fields)
+		if err != nil {
+			return err
+		}
+		length, err := readVarint(fields)
+		if err != nil {
+			return err
+		}
+		if length > uint64(fields.Len()) {
+			return ErrCorrupted
+		}
+
+		props := make([]byte, length)
+		_, err = io.ReadFull(fields, props)
+		if err != nil {
+			return err
+		}
+
+		filters = append(filters, filterFlags{id: id, props: props})
+	}
+
+	last := filters[len(filters)-1]
+	if last.id != xzFilterLZMA2 || len(last.props) != 1 {
+		return ErrUnsupported
+	}
+
+	var reader io.Reader
+	reader, err = NewReader2(x.reader, last.props[0], uncompressed)
+	if err != nil {
+		return err
+	}
+
+	for i := len(filters) - 2; i >= 0; i-- {
+		switch filters[i].id {
+		case xzFilterX86:
+			start := uint32(0)
+			if len(filters[i].props) == 4 {
+				start = binary.LittleEndian.Uint32(filters[i].props)
+			} else if len(filters[i].props) != 0 {
+				return ErrUnsupported
+			}
+			reader = NewBCJReader(reader, start)
+		case xzFilterDelta:
+			if len(filters[i].props) != 1 {
+				return ErrUnsupported
+			}
+			reader = NewDeltaReader(reader, int(filters[i].props[0])+1)
+		default:
+			return ErrUnsupported
+		}
+	}
+
+	x.block = reader
+	x.hash = x.newHash()
+	x.started = x.reader.count
+	x.blocks++
+
+	return nil
+}
+
+// Finish block by reading padding and validating check
+func (x *XZReader) finishBlock() error {
+
+	padding := (4 - (x.reader.count-x.started)%4) % 4
+	for range padding {
+		value, err := x.reader.ReadByte()
+		if err != nil {
+			return io.ErrUnexpectedEOF
+		} else if value != 0 {
+			return ErrCorrupted
+		}
+	}
+
+	check := make([]byte, checkSize(x.check))
+	_, err := io.ReadFull(x.reader, check)
+	if err != nil {
+		return io.ErrUnexpectedEOF
+	}
+
+	if x.hash != nil {
+		expected := x.hash.Sum(nil)
+		if x.check != 0x04 && x.check != 0x01 {
+			if !bytes.Equal(expected, check) {
+				return ErrChecksum
+			}
+		} else {
+			// CRC values are stored as little endian
+			for i := range expected {
+				if expected[i] != check[len(check)-1-i] {
+					return ErrChecksum
+				}
+			}
+		}
+	}
+
+	x.block = nil
+	x.hash = nil
+	return nil
+}
+
+// Skip index and stream footer after last block
+func (x *XZReader) readIndex() error {
+
+	records, err := readVarint(x.reader)
+	if err != nil {
+		return err
+	}
+	if records != uint64(x.blocks) {
+		return ErrCorrupted
+	}
+
+	for range records * 2 {
+		_, err := readVarint(x.reader)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Index padding, CRC32 and stream footer
+	for x.reader.count%4 != 0 {
+		_, err := x.reader.ReadByte()
+		if err != nil {
+			return io.ErrUnexpectedEOF
+		}
+	}
+
+	footer := make([]byte, 4+12)
+	_, err = io.ReadFull(x.reader, footer)
+	if err != nil {
+		return io.ErrUnexpectedEOF
+	}
+	if !bytes.Equal(footer[14:], xzFooterMagic) {
+		return ErrCorrupted
+	}
+
+	// Concatenated streams may follow after stream padding
+	for {
+		value, err := x.reader.reader.Peek(4)
+		if err == io.EOF || len(value) == 0 {
+			x.finished = true
+			return nil
+		} else if len(value) < 4 {
+			return ErrCorrupted
+		}
+
+		if !bytes.Equal(value, []byte{0, 0, 0, 0}) {
+			return x.readStreamHeader()
+		}
+
+		_, err = x.reader.reader.Discard(4)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Read decoded content
+func (x *XZReader) Read(p []byte) (int, error) {
+	for {
+		if x.err != nil {
+			return 0, x.err
+		}
+		if x.finished {
+			return 0, io.EOF
+		}
+
+		if x.block != nil {
+			n, err := x.block.Read(p)
+			if n > 0 && x.hash != nil {
+				x.hash.Write(p[:n])
+			}
+			if err == io.EOF {
+				x.err = x.finishBlock()
+			} else if err != nil {
+				x.err = err
+			}
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+
+		// Zero marks the index, otherwise is the size of block header
+		first, err := x.reader.ReadByte()
+		if err != nil {
+			x.err = io.ErrUnexpectedEOF
+		} else if first == 0x00 {
+			x.err = x.readIndex()
+		} else {
+			x.err = x.readBlockHeader(first)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Scan entry struct
+type ScanEntry struct {
+	Name      string `json:"name"`
+	Directory bool   `json:"directory"`
+	Size      int64  `json:"size"`
+	ModTime   int64  `json:"modTime"`
+	Inode     uint64 `json:"inode"`
+}
+
+// Scan directory struct
+// Entries are reused while modification time and inode of directory are the same
+type ScanDirectory struct {
+	ModTime int64        `json:"modTime"`
+	Inode   uint64       `json:"inode"`
+	Entries []*ScanEntry `json:"entries"`
+}
+
+// Scan index struct
+// Index keeps the content of scanned directories to avoid reading them again
+// Only directories changed since the last scan are read from disk
+type ScanIndex struct {
+	Directories map[string]*ScanDirectory `json:"directories"`
+	Cached      int                       `json:"-"`
+	Scanned     int                       `json:"-"`
+}
+
+// Read scan index from given file path
+func ReadScanIndex(path string) (*ScanIndex, error) {
+
+	index := &ScanIndex{}
+	err := ReadJSON(path, index)
+	if err != nil {
+		return index, err
+	}
+
+	if index.Directories == nil {
+		index.Directories = make(map[string]*ScanDirectory)
+	}
+
+	return index, nil
+}
+
+// Write scan index into given file path
+func WriteScanIndex(path string, index *ScanIndex) error {
+	return WriteJSON(path, index)
+}
+
+// Create scan entry from file information
+func toScanEntry(info os.FileInfo) *ScanEntry {
+	return &ScanEntry{
+		Name:      info.Name(),
+		Directory: info.IsDir(),
+		Size:      info.Size(),
+		ModTime:   info.ModTime().UnixNano(),
+		Inode:     Inode(info),
+	}
+}
+
+// Retrieve entries of directory, reading from disk only when changed
+func (i *ScanIndex) entries(path string, visited map[string]bool) ([]*ScanEntry, error) {
+
+	info, err := os.Lstat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	visited[path] = true
+	modTime := info.ModTime().UnixNano()
+	inode := Inode(info)
+
+	cached, ok := i.Directories[path]
+	if ok && cached.ModTime == modTime && cached.Inode == inode {
+		i.Cached++
+		return cached.Entries, nil
+	}
+
+	items, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*ScanEntry, 0, len(items))
+	for _, item := range items {
+		info, err := item.Info()
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, toScanEntry(info))
+	}
+
+	i.Scanned++
+	i.Directories[path] = &ScanDirectory{
+		ModTime: modTime,
+		Inode:   inode,
+		Entries: entries,
+	}
+
+	return entries, nil
+}
+
+// Walk directory tree recursively with entries from index
+func (i *ScanIndex) walk(path string, visited map[string]bool, fn func(path string, entry *ScanEntry) error) error {
+
+	entries, err := i.entries(path, visited)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		entryPath := filepath.Join(path, entry.Name)
+		err := fn(entryPath, entry)
+		if err == filepath.SkipDir {
+			if entry.Directory {
+				continue
+			}
+			break
+		} else if err != nil {
+			return err
+		}
+
+		// Symbolic links are not followed, like on filepath.WalkDir
+		if entry.Directory {
+			err := i.walk(entryPath, visited, fn)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Walk the tree of root folder in lexical order, calling fn for each entry
+// Root folder itself is not sent to fn and symbolic links are not followed
+// Directories removed since the last scan are also removed from index
+func (i *ScanIndex) Walk(root string, fn func(path string, entry *ScanEntry) error) error {
+
+	if i.Directories == nil {
+		i.Directories = make(map[string]*ScanDirectory)
+	}
+
+	visited := make(map[string]bool)
+	err := i.walk(root, visited, fn)
+	if err != nil {
+		return err
+	}
+
+	separator := string(os.PathSeparator)
+	for path := range i.Directories {
+		inside := path == root || strings.HasPrefix(path, root+separator)
+		if inside && !visited[path] {
+			delete(i.Directories, path)
+		}
+	}
+
+	return nil
+}
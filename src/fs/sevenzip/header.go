@@ -0,0 +1,774 @@
+package sevenzip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"unicode/utf16"
+)
+
+// Property identifiers of archive header
+const (
+	idEnd                   = 0x00
+	idHeader                = 0x01
+	idArchiveProperties     = 0x02
+	idAdditionalStreamsInfo = 0x03
+	idMainStreamsInfo       = 0x04
+	idFilesInfo             = 0x05
+	idPackInfo              = 0x06
+	idUnpackInfo            = 0x07
+	idSubStreamsInfo        = 0x08
+	idSize                  = 0x09
+	idCRC                   = 0x0A
+	idFolder                = 0x0B
+	idCodersUnpackSize      = 0x0C
+	idNumUnpackStream       = 0x0D
+	idEmptyStream           = 0x0E
+	idEmptyFile             = 0x0F
+	idName                  = 0x11
+	idWinAttributes         = 0x15
+	idEncodedHeader         = 0x17
+	idDummy                 = 0x19
+)
+
+// Limits of archive structures to reject malformed archives
+const (
+	maxFolderStreams = 64
+	maxHeaderSize    = 1 << 26
+	maxHeaderDepth   = 4
+)
+
+// Errors of the archive reader
+var (
+	ErrFormat      = errors.New("7z: invalid archive format")
+	ErrUnsupported = errors.New("7z: unsupported archive feature")
+	ErrChecksum    = errors.New("7z: checksum mismatch")
+)
+
+// Coder of folder
+type coder struct {
+	method     []byte
+	inStreams  int
+	outStreams int
+	properties []byte
+}
+
+// Bind pair connecting coder input to output of another coder
+type bindPair struct {
+	inIndex  int
+	outIndex int
+}
+
+// Folder is a group of coders producing a single stream
+type folder struct {
+	coders        []*coder
+	bindPairs     []bindPair
+	packedStreams []int
+	unpackSizes   []int64
+	crc           uint32
+	hasCRC        bool
+	firstPack     int
+	unpackStreams int
+}
+
+// Streams information of archive
+type streamsInfo struct {
+	packPos   int64
+	packSizes []int64
+	folders   []*folder
+	subSizes  []int64
+	subCRCs   []uint32
+	subHasCRC []bool
+}
+
+// Header parser over buffer
+type parser struct {
+	*bytes.Reader
+}
+
+// Read single byte
+func (p *parser) byte() (byte, error) {
+	value, err := p.ReadByte()
+	if err != nil {
+		return 0, ErrFormat
+	}
+	return value, nil
+}
+
+// Read number with variable length encoding
+func (p *parser) number() (uint64, error) {
+	first, err := p.byte()
+	if err != nil {
+		return 0, err
+	}
+
+	var result uint64
+	mask := byte(0x80)
+	for i := range 8 {
+		if first&mask == 0 {
+			high := uint64(first & (mask - 1))
+			return result | high<<(8*i), nil
+		}
+
+		value, err := p.byte()
+		if err != nil {
+			return 0, err
+		}
+		result |= uint64(value) << (8 * i)
+		mask >>= 1
+	}
+
+	return result, nil
+}
+
+// Read number limited to a reasonable count of items
+func (p *parser) count() (int, error) {
+	value, err := p.number()
+	if err != nil {
+		return 0, err
+	} else if value > uint64(p.Len())+1 && value > 1<<16 {
+		return 0, ErrFormat
+	}
+	return int(value), nil
+}
+
+// Read size of stream, rejecting values that do not fit on int64
+func (p *parser) size() (int64, error) {
+	value, err := p.number()
+	if err != nil {
+		return 0, err
+	} else if value > math.MaxInt64 {
+		return 0, ErrFormat
+	}
+	return int64(value), nil
+}
+
+// Read fixed size bytes
+func (p *parser) bytes(size int) ([]byte, error) {
+	if size < 0 || size > p.Len() {
+		return nil, ErrFormat
+	}
+	result := make([]byte, size)
+	_, err := io.ReadFull(p, result)
+	return result, err
+}
+
+// Read 32 bits unsigned integer
+func (p *parser) uint32() (uint32, error) {
+	value, err := p.bytes(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(value), nil
+}
+
+// Read bit vector with given number of items
+func (p *parser) bits(items int) ([]bool, error) {
+	result := make([]bool, items)
+	var value byte
+	var err error
+	for i := range items {
+		if i%8 == 0 {
+			value, err = p.byte()
+			if err != nil {
+				return nil, err
+			}
+		}
+		result[i] = value&(0x80>>(i%8)) != 0
+	}
+	return result, nil
+}
+
+// Read bit vector that can be marked as all defined
+func (p *parser) optionalBits(items int) ([]bool, error) {
+	all, err := p.byte()
+	if err != nil {
+		return nil, err
+	}
+	if all == 0 {
+		return p.bits(items)
+	}
+
+	result := make([]bool, items)
+	for i := range result {
+		result[i] = true
+	}
+	return result, nil
+}
+
+// Read digests of items
+func (p *parser) digests(items int) ([]uint32, []bool, error) {
+	defined, err := p.optionalBits(items)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := make([]uint32, items)
+	for i := range items {
+		if defined[i] {
+			result[i], err = p.uint32()
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	return result, defined, nil
+}
+
+// Skip property data with size prefix
+func (p *parser) skip() error {
+	size, err := p.number()
+	if err != nil {
+		return err
+	}
+	_, err = p.bytes(int(size))
+	return err
+}
+
+// Read pack information
+func (p *parser) packInfo(info *streamsInfo) error {
+
+	position, err := p.size()
+	if err != nil {
+		return err
+	}
+	streams, err := p.count()
+	if err != nil {
+		return err
+	}
+
+	info.packPos = position
+	info.packSizes = make([]int64, streams)
+
+	for {
+		id, err := p.byte()
+		if err != nil {
+			return err
+		}
+
+		switch id {
+		case idEnd:
+			return nil
+		case idSize:
+			for i := range streams {
+				info.packSizes[i], err = p.size()
+				if err != nil {
+					return err
+				}
+			}
+		case idCRC:
+			_, _, err = p.digests(streams)
+			if err != nil {
+				return err
+			}
+		default:
+			err = p.skip()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Read folder definition
+func (p *parser) folder() (*folder, error) {
+
+	result := &folder{}
+	coders, err := p.count()
+	if err != nil {
+		return nil, err
+	} else if coders > maxFolderStreams {
+		return nil, ErrUnsupported
+	}
+
+	totalIn := 0
+	totalOut := 0
+	for range coders {
+		flags, err := p.byte()
+		if err != nil {
+			return nil, err
+		}
+		if flags&0x80 != 0 {
+			return nil, ErrUnsupported
+		}
+
+		item := &coder{inStreams: 1, outStreams: 1}
+		item.method, err = p.bytes(int(flags & 0x0F))
+		if err != nil {
+			return nil, err
+		}
+
+		if flags&0x10 != 0 {
+			item.inStreams, err = p.count()
+			if err != nil {
+				return nil, err
+			}
+			item.outStreams, err = p.count()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if flags&0x20 != 0 {
+			size, err := p.count()
+			if err != nil {
+				return nil, err
+			}
+			item.properties, err = p.bytes(size)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		totalIn += item.inStreams
+		totalOut += item.outStreams
+		if totalIn > maxFolderStreams || totalOut > maxFolderStreams {
+			return nil, ErrUnsupported
+		}
+
+		result.coders = append(result.coders, item)
+	}
+
+	if totalOut == 0 {
+		return nil, ErrFormat
+	}
+
+	for range totalOut - 1 {
+		inIndex, err := p.count()
+		if err != nil {
+			return nil, err
+		}
+		outIndex, err := p.count()
+		if err != nil {
+			return nil, err
+		}
+		if inIndex >= totalIn || outIndex >= totalOut {
+			return nil, ErrFormat
+		}
+		result.bindPairs = append(result.bindPairs, bindPair{inIndex, outIndex})
+	}
+
+	packed := totalIn - len(result.bindPairs)
+	if packed < 1 {
+		return nil, ErrFormat
+	}
+
+	if packed == 1 {
+		for i := range totalIn {
+			if result.findInBinding(i) < 0 {
+				result.packedStreams = append(result.packedStreams, i)
+				break
+			}
+		}
+	} else {
+		for range packed {
+			index, err := p.count()
+			if err != nil {
+				return nil, err
+			} else if index >= totalIn {
+				return nil, ErrFormat
+			}
+			result.packedStreams = append(result.packedStreams, index)
+		}
+	}
+
+	return result, nil
+}
+
+// Read unpack information with folders
+func (p *parser) unpackInfo(info *streamsInfo) error {
+
+	id, err := p.byte()
+	if err != nil {
+		return err
+	} else if id != idFolder {
+		return ErrFormat
+	}
+
+	folders, err := p.count()
+	if err != nil {
+		return err
+	}
+
+	external, err := p.byte()
+	if err != nil {
+		return err
+	} else if external != 0 {
+		return ErrUnsupported
+	}
+
+	info.folders = make([]*folder, folders)
+	for i := range folders {
+		info.folders[i], err = p.folder()
+		if err != nil {
+			return err
+		}
+	}
+
+	id, err = p.byte()
+	if err != nil {
+		return err
+	} else if id != idCodersUnpackSize {
+		return ErrFormat
+	}
+
+	for _, item := range info.folders {
+		outputs := 0
+		for _, coder := range item.coders {
+			outputs += coder.outStreams
+		}
+
+		item.unpackSizes = make([]int64, outputs)
+		for i := range outputs {
+			item.unpackSizes[i], err = p.size()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		id, err := p.byte()
+		if err != nil {
+			return err
+		}
+
+		switch id {
+		case idEnd:
+			return nil
+		case idCRC:
+			crcs, defined, err := p.digests(folders)
+			if err != nil {
+				return err
+			}
+			for i, item := range info.folders {
+				item.crc = crcs[i]
+				item.hasCRC = defined[i]
+			}
+		default:
+			err = p.skip()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Read sub streams information with files of each folder
+func (p *parser) subStreamsInfo(info *streamsInfo) error {
+
+	for _, item := range info.folders {
+		item.unpackStreams = 1
+	}
+
+	id, err := p.byte()
+	if err != nil {
+		return err
+	}
+
+	if id == idNumUnpackStream {
+		for _, item := range info.folders {
+			item.unpackStreams, err = p.count()
+			if err != nil {
+				return err
+			}
+		}
+
+		id, err = p.byte()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Sizes are declared except for the last stream of each folder
+	info.subSizes = []int64{}
+	for _, item := range info.folders {
+		if item.unpackStreams == 0 {
+			continue
+		}
+
+		// Sizes of streams can not exceed the size of folder
+		var sum int64
+		total := item.unpackSize()
+		if id == idSize {
+			for range item.unpackStreams - 1 {
+				size, err := p.size()
+				if err != nil {
+					return err
+				} else if size > total-sum {
+					return ErrFormat
+				}
+				sum += size
+				info.subSizes = append(info.subSizes, size)
+			}
+		} else if item.unpackStreams > 1 {
+			return ErrFormat
+		}
+
+		info.subSizes = append(info.subSizes, total-sum)
+	}
+
+	if id == idSize {
+		id, err = p.byte()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Folders with single stream and CRC already have the digest
+	info.subCRCs = make([]uint32, len(info.subSizes))
+	info.subHasCRC = make([]bool, len(info.subSizes))
+
+	index := 0
+	for _, item := range info.folders {
+		if item.unpackStreams == 1 && item.hasCRC {
+			info.subCRCs[index] = item.crc
+			info.subHasCRC[index] = true
+		}
+		index += item.unpackStreams
+	}
+
+	for id != idEnd {
+		if id == idCRC {
+			missing := 0
+			for _, item := range info.folders {
+				if item.unpackStreams != 1 || !item.hasCRC {
+					missing += item.unpackStreams
+				}
+			}
+
+			crcs, defined, err := p.digests(missing)
+			if err != nil {
+				return err
+			}
+
+			index := 0
+			next := 0
+			for _, item := range info.folders {
+				if item.unpackStreams == 1 && item.hasCRC {
+					index++
+					continue
+				}
+				for range item.unpackStreams {
+					info.subCRCs[index] = crcs[next]
+					info.subHasCRC[index] = defined[next]
+					index++
+					next++
+				}
+			}
+		} else {
+			err = p.skip()
+			if err != nil {
+				return err
+			}
+		}
+
+		id, err = p.byte()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Read streams information
+func (p *parser) streamsInfo() (*streamsInfo, error) {
+
+	info := &streamsInfo{}
+	subStreams := false
+
+	for {
+		id, err := p.byte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch id {
+		case idEnd:
+			// Without sub streams each folder has a single stream
+			if !subStreams {
+				for _, item := range info.folders {
+					item.unpackStreams = 1
+					info.subSizes = append(info.subSizes, item.unpackSize())
+					info.subCRCs = append(info.subCRCs, item.crc)
+					info.subHasCRC = append(info.subHasCRC, item.hasCRC)
+				}
+			}
+			return info, nil
+		case idPackInfo:
+			err = p.packInfo(info)
+		case idUnpackInfo:
+			err = p.unpackInfo(info)
+		case idSubStreamsInfo:
+			subStreams = true
+			err = p.subStreamsInfo(info)
+		default:
+			return nil, ErrFormat
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Read files information
+func (p *parser) filesInfo() ([]*File, error) {
+
+	count, err := p.count()
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*File, count)
+	for i := range files {
+		files[i] = &File{}
+	}
+
+	emptyStreams := make([]bool, count)
+	emptyFiles := []bool{}
+	numEmpty := 0
+
+	for {
+		id, err := p.byte()
+		if err != nil {
+			return nil, err
+		} else if id == idEnd {
+			break
+		}
+
+		size, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		data, err := p.bytes(int(size))
+		if err != nil {
+			return nil, err
+		}
+
+		property := &parser{bytes.NewReader(data)}
+		switch id {
+		case idEmptyStream:
+			emptyStreams, err = property.bits(count)
+			numEmpty = 0
+			for _, empty := range emptyStreams {
+				if empty {
+					numEmpty++
+				}
+			}
+		case idEmptyFile:
+			emptyFiles, err = property.bits(numEmpty)
+		case idName:
+			err = property.names(files)
+		case idWinAttributes:
+			err = property.attributes(files)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Empty streams are directories unless marked as empty files
+	emptyIndex := 0
+	for i, file := range files {
+		file.hasStream = !emptyStreams[i]
+		if !file.hasStream {
+			isFile := emptyIndex < len(emptyFiles) && emptyFiles[emptyIndex]
+			file.IsDirectory = !isFile
+			emptyIndex++
+		}
+	}
+
+	return files, nil
+}
+
+// Read file names encoded as UTF-16
+func (p *parser) names(files []*File) error {
+
+	external, err := p.byte()
+	if err != nil {
+		return err
+	} else if external != 0 {
+		return ErrUnsupported
+	}
+
+	for _, file := range files {
+		name := []uint16{}
+		for {
+			value, err := p.bytes(2)
+			if err != nil {
+				return err
+			}
+
+			char := binary.LittleEndian.Uint16(value)
+			if char == 0 {
+				break
+			}
+			name = append(name, char)
+		}
+
+		file.Name = string(utf16.Decode(name))
+	}
+
+	return nil
+}
+
+// Read file attributes
+func (p *parser) attributes(files []*File) error {
+
+	defined, err := p.optionalBits(len(files))
+	if err != nil {
+		return err
+	}
+
+	external, err := p.byte()
+	if err != nil {
+		return err
+	} else if external != 0 {
+		return ErrUnsupported
+	}
+
+	for i, file := range files {
+		if defined[i] {
+			file.Attributes, err = p.uint32()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Find bind pair connected to given input stream
+func (f *folder) findInBinding(index int) int {
+	for i, pair := range f.bindPairs {
+		if pair.inIndex == index {
+			return i
+		}
+	}
+	return -1
+}
+
+// Find bind pair connected to given output stream
+func (f *folder) findOutBinding(index int) int {
+	for i, pair := range f.bindPairs {
+		if pair.outIndex == index {
+			return i
+		}
+	}
+	return -1
+}
+
+// Retrieve final unpack size of folder
+func (f *folder) unpackSize() int64 {
+	for i := len(f.unpackSizes) - 1; i >= 0; i-- {
+		if f.findOutBinding(i) < 0 {
+			return f.unpackSizes[i]
+		}
+	}
+	return 0
+}
@@ -0,0 +1,459 @@
+package sevenzip
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"github.com/mateussouzaweb/nicedeck/src/fs/lzma"
+)
+
+// Signature of 7z archives
+var signature = []byte{'7', 'z', 0xBC, 0xAF, 0x27, 0x1C}
+
+// Size of the signature header
+const signatureHeaderSize = 32
+
+// File struct
+// File represents an entry of the archive
+type File struct {
+	Name        string
+	Size        int64
+	Attributes  uint32
+	IsDirectory bool
+	hasStream   bool
+	crc         uint32
+	hasCRC      bool
+}
+
+// Retrieve file mode from attributes
+// Unix permissions are available when created on Unix systems
+func (f *File) Mode() os.FileMode {
+
+	if f.Attributes&0x8000 != 0 {
+		unix := f.Attributes >> 16
+		mode := os.FileMode(unix & 0777)
+		switch unix & 0xF000 {
+		case 0xA000:
+			mode |= os.ModeSymlink
+		case 0x4000:
+			mode |= os.ModeDir
+		}
+		if f.IsDirectory {
+			mode |= os.ModeDir
+		}
+		return mode
+	}
+
+	if f.IsDirectory || f.Attributes&0x10 != 0 {
+		return os.ModeDir | 0755
+	}
+
+	return 0644
+}
+
+// Archive struct
+// Archive gives access to files of an opened 7z archive
+type Archive struct {
+	Files []*File
+	file  *os.File
+	size  int64
+	info  *streamsInfo
+}
+
+// Open 7z archive at given path
+func Open(path string) (*Archive, error) {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := &Archive{file: file, info: &streamsInfo{}}
+	err = archive.readHeaders()
+	if err != nil {
+		return nil, errors.Join(err, file.Close())
+	}
+
+	return archive, nil
+}
+
+// Close archive file
+func (a *Archive) Close() error {
+	return a.file.Close()
+}
+
+// Read signature header and archive headers
+func (a *Archive) readHeaders() error {
+
+	header := make([]byte, signatureHeaderSize)
+	_, err := io.ReadFull(a.file, header)
+	if err != nil {
+		return ErrFormat
+	}
+
+	if !bytes.Equal(header[:6], signature) {
+		return ErrFormat
+	}
+	if crc32.ChecksumIEEE(header[12:32]) != binary.LittleEndian.Uint32(header[8:12]) {
+		return ErrFormat
+	}
+
+	offset := binary.LittleEndian.Uint64(header[12:20])
+	size := binary.LittleEndian.Uint64(header[20:28])
+	checksum := binary.LittleEndian.Uint32(header[28:32])
+
+	// Empty archive without headers
+	if size == 0 {
+		return nil
+	}
+
+	stat, err := a.file.Stat()
+	if err != nil {
+		return err
+	}
+	a.size = stat.Size()
+	if offset > uint64(stat.Size()) || size > uint64(stat.Size())-offset {
+		return ErrFormat
+	}
+
+	data := make([]byte, size)
+	_, err = a.file.ReadAt(data, signatureHeaderSize+int64(offset))
+	if err != nil {
+		return ErrFormat
+	}
+	if crc32.ChecksumIEEE(data) != checksum {
+		return ErrChecksum
+	}
+
+	// Header can be compressed multiple times, but only a few levels deep
+	for range maxHeaderDepth {
+		p := &parser{bytes.NewReader(data)}
+		id, err := p.byte()
+		if err != nil {
+			return err
+		}
+
+		switch id {
+		case idHeader:
+			return a.readHeader(p)
+		case idEncodedHeader:
+			info, err := p.streamsInfo()
+			if err != nil {
+				return err
+			}
+			data, err = a.decodeFolder(info, 0)
+			if err != nil {
+				return err
+			}
+		default:
+			return ErrFormat
+		}
+	}
+
+	return ErrUnsupported
+}
+
+// Read main header with streams and files information
+func (a *Archive) readHeader(p *parser) error {
+
+	for {
+		id, err := p.byte()
+		if err != nil {
+			return err
+		}
+
+		switch id {
+		case idEnd:
+			return a.assignStreams()
+		case idArchiveProperties:
+			for {
+				kind, err := p.byte()
+				if err != nil {
+					return err
+				} else if kind == 0 {
+					break
+				}
+				err = p.skip()
+				if err != nil {
+					return err
+				}
+			}
+		case idAdditionalStreamsInfo:
+			_, err = p.streamsInfo()
+		case idMainStreamsInfo:
+			a.info, err = p.streamsInfo()
+		case idFilesInfo:
+			a.Files, err = p.filesInfo()
+		default:
+			return ErrFormat
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Assign size and checksum of streams to files
+func (a *Archive) assignStreams() error {
+
+	pack := 0
+	for _, item := range a.info.folders {
+		item.firstPack = pack
+		pack += len(item.packedStreams)
+	}
+	if pack > len(a.info.packSizes) {
+		return ErrFormat
+	}
+
+	index := 0
+	for _, file := range a.Files {
+		if !file.hasStream {
+			continue
+		}
+		if index >= len(a.info.subSizes) {
+			return ErrFormat
+		}
+
+		file.Size = a.info.subSizes[index]
+		file.crc = a.info.subCRCs[index]
+		file.hasCRC = a.info.subHasCRC[index]
+		index++
+	}
+
+	return nil
+}
+
+// Retrieve reader of packed stream
+func (a *Archive) packReader(info *streamsInfo, index int) (io.Reader, error) {
+
+	if index >= len(info.packSizes) {
+		return nil, ErrFormat
+	}
+
+	// Packed stream must be inside the archive file
+	offset := signatureHeaderSize + info.packPos
+	for i := range index + 1 {
+		if offset > a.size || info.packSizes[i] > a.size-offset {
+			return nil, ErrFormat
+		}
+		if i < index {
+			offset += info.packSizes[i]
+		}
+	}
+
+	section := io.NewSectionReader(a.file, offset, info.packSizes[index])
+	return bufio.NewReaderSize(section, 1<<16), nil
+}
+
+// Retrieve reader of coder output stream inside folder
+// Depth is limited to the number of coders to detect cycles of bind pairs
+func (a *Archive) coderReader(info *streamsInfo, item *folder, outIndex int, depth int) (io.Reader, error) {
+
+	if depth > len(item.coders) {
+		return nil, ErrFormat
+	}
+	if outIndex < 0 || outIndex >= len(item.unpackSizes) {
+		return nil, ErrFormat
+	}
+
+	// Locate coder with the output stream and its first input stream
+	inBase := 0
+	outBase := 0
+	var current *coder
+	for _, c := range item.coders {
+		if outIndex < outBase+c.outStreams {
+			current = c
+			break
+		}
+		inBase += c.inStreams
+		outBase += c.outStreams
+	}
+	if current == nil {
+		return nil, ErrFormat
+	}
+	if current.inStreams != 1 || current.outStreams != 1 {
+		return nil, ErrUnsupported
+	}
+
+	// Input comes from another coder or from a packed stream
+	var input io.Reader
+	var err error
+	if pair := item.findInBinding(inBase); pair >= 0 {
+		input, err = a.coderReader(info, item, item.bindPairs[pair].outIndex, depth+1)
+	} else {
+		found := -1
+		for i, index := range item.packedStreams {
+			if index == inBase {
+				found = i
+			}
+		}
+		if found < 0 {
+			return nil, ErrFormat
+		}
+		input, err = a.packReader(info, item.firstPack+found)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newMethodReader(current, input, item.unpackSizes[outIndex])
+}
+
+// Create reader that decodes input with coder method
+func newMethodReader(c *coder, input io.Reader, size int64) (io.Reader, error) {
+
+	method := string(c.method)
+	switch method {
+	case "\x00":
+		return input, nil
+	case "\x21":
+		if len(c.properties) != 1 {
+			return nil, ErrFormat
+		}
+		return lzma.NewReader2(input, c.properties[0], size)
+	case "\x03\x01\x01":
+		return lzma.NewReader(input, c.properties, size)
+	case "\x03\x03\x01\x03":
+		return lzma.NewBCJReader(input, 0), nil
+	case "\x03":
+		if len(c.properties) != 1 {
+			return nil, ErrFormat
+		}
+		return lzma.NewDeltaReader(input, int(c.properties[0])+1), nil
+	case "\x04\x01\x08":
+		return flate.NewReader(input), nil
+	case "\x04\x02\x02":
+		return bzip2.NewReader(input), nil
+	}
+
+	return nil, ErrUnsupported
+}
+
+// Retrieve reader of folder content
+func (a *Archive) folderReader(info *streamsInfo, item *folder) (io.Reader, error) {
+	for i := len(item.unpackSizes) - 1; i >= 0; i-- {
+		if item.findOutBinding(i) < 0 {
+			return a.coderReader(info, item, i, 0)
+		}
+	}
+	return nil, ErrFormat
+}
+
+// Decode full content of folder
+func (a *Archive) decodeFolder(info *streamsInfo, index int) ([]byte, error) {
+
+	if index >= len(info.folders) {
+		return nil, ErrFormat
+	}
+
+	pack := 0
+	for _, item := range info.folders {
+		item.firstPack = pack
+		pack += len(item.packedStreams)
+	}
+
+	item := info.folders[index]
+	reader, err := a.folderReader(info, item)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decoded content is kept in memory, so only headers are decoded here
+	size := item.unpackSize()
+	if size > maxHeaderSize {
+		return nil, ErrUnsupported
+	}
+
+	data := make([]byte, size)
+	_, err = io.ReadFull(reader, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.hasCRC && crc32.ChecksumIEEE(data) != item.crc {
+		return nil, ErrChecksum
+	}
+
+	return data, nil
+}
+
+// Counter of written bytes
+type counter int64
+
+// Count written bytes
+func (c *counter) Write(p []byte) (int, error) {
+	*c += counter(len(p))
+	return len(p), nil
+}
+
+// Walk files of the archive in order with a reader of their content
+// Content is decoded sequentially, so readers are valid only during callback
+func (a *Archive) Walk(callback func(file *File, content io.Reader) error) error {
+
+	folderIndex := 0
+	remaining := 0
+	var reader io.Reader
+
+	for _, file := range a.Files {
+
+		if !file.hasStream {
+			err := callback(file, bytes.NewReader(nil))
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Move to the next folder when all streams were read
+		for remaining == 0 {
+			if folderIndex >= len(a.info.folders) {
+				return ErrFormat
+			}
+
+			item := a.info.folders[folderIndex]
+			folderIndex++
+			remaining = item.unpackStreams
+			if remaining == 0 {
+				continue
+			}
+
+			var err error
+			reader, err = a.folderReader(a.info, item)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Validate content size and checksum when all content was consumed
+		hash := crc32.NewIEEE()
+		consumed := new(counter)
+		limited := io.LimitReader(reader, file.Size)
+		content := io.TeeReader(limited, io.MultiWriter(hash, consumed))
+		err := callback(file, content)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(io.Discard, content)
+		if err != nil {
+			return err
+		}
+
+		remaining--
+		if int64(*consumed) != file.Size {
+			return io.ErrUnexpectedEOF
+		}
+		if file.hasCRC && hash.Sum32() != file.crc {
+			return ErrChecksum
+		}
+	}
+
+	return nil
+}
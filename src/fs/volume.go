@@ -0,0 +1,155 @@
+package fs
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+)
+
+// Volume struct
+// Volume represents the filesystem where a path lives on
+// ID is the filesystem UUID when available, or the best stable alternative
+type Volume struct {
+	ID         string `json:"id"`
+	Label      string `json:"label"`
+	MountPoint string `json:"mountPoint"`
+}
+
+// Check if path is inside the given folder path
+func isInside(path string, folder string) bool {
+	if folder == string(os.PathSeparator) {
+		return strings.HasPrefix(path, folder)
+	}
+	return path == folder || strings.HasPrefix(path, folder+string(os.PathSeparator))
+}
+
+// Find link name in folder that resolves to the given device
+func findDeviceLink(folder string, device string) string {
+
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return ""
+	}
+
+	for _, entry := range entries {
+		target, err := filepath.EvalSymlinks(filepath.Join(folder, entry.Name()))
+		if err == nil && target == device {
+			return entry.Name()
+		}
+	}
+
+	return ""
+}
+
+// Unescape octal sequences used by mount information on Linux
+func unescapeMount(value string) string {
+	replacer := strings.NewReplacer(`\040`, " ", `\011`, "\t", `\012`, "\n", `\134`, `\`)
+	return replacer.Replace(value)
+}
+
+// Retrieve volume of path on Linux from mount information
+func getLinuxVolume(path string) (volume *Volume, err error) {
+
+	volume = &Volume{}
+	file, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return volume, err
+	}
+
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	// Find the deepest mount point that contains the path
+	// Format: ID PARENT MAJOR:MINOR ROOT MOUNT OPTIONS ... - TYPE SOURCE OPTIONS
+	device := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+
+		mountPoint := unescapeMount(fields[4])
+		if !isInside(path, mountPoint) || len(mountPoint) < len(volume.MountPoint) {
+			continue
+		}
+
+		volume.MountPoint = mountPoint
+		volume.ID = fields[2]
+		device = ""
+		for index, field := range fields {
+			if field == "-" && index+2 < len(fields) {
+				device = unescapeMount(fields[index+2])
+				break
+			}
+		}
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return volume, err
+	}
+
+	// Resolve filesystem UUID and label from device links
+	if strings.HasPrefix(device, "/dev/") {
+		device, _ = filepath.EvalSymlinks(device)
+		if uuid := findDeviceLink("/dev/disk/by-uuid", device); uuid != "" {
+			volume.ID = uuid
+		} else {
+			volume.ID = device
+		}
+		volume.Label = unescapeMount(findDeviceLink("/dev/disk/by-label", device))
+	}
+
+	return volume, err
+}
+
+// Retrieve volume where the given path lives on
+// Path must exist, otherwise the volume cannot be detected
+func GetVolume(path string) (*Volume, error) {
+
+	volume := &Volume{}
+	realPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return volume, err
+	}
+
+	realPath, err = filepath.Abs(realPath)
+	if err != nil {
+		return volume, err
+	}
+
+	if cli.IsLinux() {
+		return getLinuxVolume(realPath)
+	}
+
+	// On MacOS, external volumes are mounted under the volumes folder
+	if cli.IsMacOS() {
+		parts := strings.Split(strings.TrimPrefix(realPath, "/"), "/")
+		if len(parts) >= 2 && parts[0] == "Volumes" {
+			volume.ID = parts[1]
+			volume.Label = parts[1]
+			volume.MountPoint = filepath.Join("/Volumes", parts[1])
+		} else {
+			volume.ID = "/"
+			volume.MountPoint = "/"
+		}
+		return volume, nil
+	}
+
+	// On Windows, each drive letter is a volume
+	if cli.IsWindows() {
+		name := filepath.VolumeName(realPath)
+		volume.ID = strings.ToUpper(name)
+		volume.Label = strings.ToUpper(name)
+		volume.MountPoint = name + `\`
+		return volume, nil
+	}
+
+	return volume, nil
+}
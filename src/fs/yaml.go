@@ -0,0 +1,62 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/yaml"
+)
+
+// Read YAML from file content and put into target
+func ReadYAML(path string, target any) error {
+
+	// Check if file exist
+	exist, err := FileExist(path)
+	if err != nil {
+		return err
+	} else if exist {
+
+		cli.Debug("Reading YAML %s\n", path)
+
+		// Read file content
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		// Write decoded content to target pointer
+		err = yaml.Unmarshal(content, target)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Write YAML content from source into target path
+func WriteYAML(path string, source any) error {
+
+	cli.Debug("Writing YAML at %s\n", path)
+
+	// Convert source to YAML representation
+	content, err := yaml.Marshal(source)
+	if err != nil {
+		return err
+	}
+
+	// Make sure destination folder path exist
+	err = os.MkdirAll(filepath.Dir(path), 0774)
+	if err != nil {
+		return err
+	}
+
+	// Write YAML content to file
+	err = os.WriteFile(path, content, 0666)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package gog
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+	"github.com/mateussouzaweb/nicedeck/src/packaging"
+	"github.com/mateussouzaweb/nicedeck/src/packaging/proton"
+	"github.com/mateussouzaweb/nicedeck/src/sqlite"
+)
+
+// Game struct
+type Game struct {
+	ProductID        int64  `json:"productId"`
+	Title            string `json:"title"`
+	InstallationPath string `json:"installationPath"`
+}
+
+// Retrieve Galaxy database path for the given package
+func GetDatabasePath(target packaging.Package) string {
+	if target.Runtime() == "proton" {
+		return proton.ResolvePath(target, "C:/ProgramData/GOG.com/Galaxy/storage/galaxy-2.0.db")
+	} else if cli.IsMacOS() {
+		return "/Users/Shared/GOG.com/Galaxy/Storage/galaxy-2.0.db"
+	} else if cli.IsWindows() {
+		return fs.ExpandPath("$HOMEDRIVE/ProgramData/GOG.com/Galaxy/storage/galaxy-2.0.db")
+	}
+
+	return ""
+}
+
+// Read installed games from Galaxy database
+func GetInstalledGames(databasePath string) ([]*Game, error) {
+
+	games := []*Game{}
+
+	exist, err := fs.FileExist(databasePath)
+	if err != nil {
+		return games, err
+	} else if !exist {
+		return games, nil
+	}
+
+	database, err := sqlite.Open(databasePath)
+	if err != nil {
+		return games, err
+	}
+
+	// Older Galaxy versions may not have these tables yet
+	installed, err := database.Table("InstalledBaseProducts")
+	if errors.Is(err, sqlite.ErrTableNotFound) {
+		return games, nil
+	} else if err != nil {
+		return games, err
+	}
+
+	details, err := database.Table("LimitedDetails")
+	if err != nil && !errors.Is(err, sqlite.ErrTableNotFound) {
+		return games, err
+	}
+
+	// Map product titles
+	titles := map[int64]string{}
+	for _, row := range details {
+		productID, _ := row["productId"].(int64)
+		title, _ := row["title"].(string)
+		if productID != 0 && title != "" {
+			titles[productID] = title
+		}
+	}
+
+	for _, row := range installed {
+		productID, _ := row["productId"].(int64)
+		installationPath, _ := row["installationPath"].(string)
+		if productID == 0 || installationPath == "" {
+			continue
+		}
+
+		// Fallback to folder name when title is not available
+		title := titles[productID]
+		if title == "" {
+			title = filepath.Base(strings.ReplaceAll(installationPath, "\\", "/"))
+		}
+
+		games = append(games, &Game{
+			ProductID:        productID,
+			Title:            title,
+			InstallationPath: installationPath,
+		})
+	}
+
+	return games, nil
+}
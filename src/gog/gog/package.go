@@ -0,0 +1,36 @@
+package gog
+
+import (
+	"github.com/mateussouzaweb/nicedeck/src/packaging"
+	"github.com/mateussouzaweb/nicedeck/src/packaging/macos"
+	"github.com/mateussouzaweb/nicedeck/src/packaging/proton"
+	"github.com/mateussouzaweb/nicedeck/src/packaging/windows"
+	"github.com/mateussouzaweb/nicedeck/src/programs/website"
+)
+
+// Retrieve GOG Galaxy package
+func GetPackage() packaging.Package {
+	return packaging.Best(&proton.Proton{
+		AppID:       "gog-galaxy",
+		Installer:   "$DOWNLOADS/GOG_Galaxy_2.0.exe",
+		Uninstaller: "$PROGRAMS/GOG Galaxy/unins000.exe",
+		Launcher:    "$PROGRAMS/GOG Galaxy/GalaxyClient.exe",
+		Arguments: &packaging.Arguments{
+			Install:  []string{"/silent"},
+			Remove:   []string{"/SILENT"},
+			Shortcut: []string{},
+		},
+		Source: website.Release(
+			"https://www.gog.com/galaxy", "",
+			"https:*/download/GOG_Galaxy_2.0.exe",
+		),
+	}, &macos.Homebrew{
+		AppID:     "gog-galaxy",
+		Launcher:  "/Applications/GOG Galaxy.app",
+		Arguments: packaging.NoArguments(),
+	}, &windows.WinGet{
+		AppID:     "GOG.Galaxy",
+		Launcher:  "$PROGRAMS/GOG Galaxy/GalaxyClient.exe",
+		Arguments: packaging.NoArguments(),
+	})
+}
@@ -0,0 +1,122 @@
+package gog
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+	"github.com/mateussouzaweb/nicedeck/src/gog/gog"
+	"github.com/mateussouzaweb/nicedeck/src/shortcuts"
+)
+
+// Shortcut alias
+type Shortcut = shortcuts.Shortcut
+
+// Library struct
+type Library struct {
+	DatabasePath string  `json:"databasePath"`
+	Games        []*Game `json:"games"`
+}
+
+// String representation of the library
+func (l *Library) String() string {
+	return "GOG Galaxy"
+}
+
+// Operation mode of the library
+func (l *Library) Mode() string {
+	return "IMPORT"
+}
+
+// Init library
+func (l *Library) Init(databasePath string) error {
+	l.DatabasePath = databasePath
+	return nil
+}
+
+// Load library
+func (l *Library) Load() error {
+
+	// Reset and fill basic information
+	l.Games = make([]*Game, 0)
+
+	// Check if GOG Galaxy is installed
+	gogPackage := gog.GetPackage()
+	installed, err := gogPackage.Installed()
+	if err != nil {
+		return err
+	} else if !installed {
+		return nil
+	}
+
+	// Read installed games from Galaxy database
+	games, err := GetInstalledGames(GetDatabasePath(gogPackage))
+	if err != nil {
+		return err
+	}
+
+	l.Games = games
+	return nil
+}
+
+// Save library
+func (l *Library) Save() error {
+
+	// Save database state to file
+	err := fs.WriteJSON(l.DatabasePath, l)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Export shortcuts to internal format
+// Installed games launch through the Galaxy client command
+func (l *Library) Export() []*Shortcut {
+
+	results := make([]*Shortcut, 0)
+	gogPackage := gog.GetPackage()
+	executable := gogPackage.Executable()
+
+	for _, game := range l.Games {
+		arguments := append([]string{}, gogPackage.Args()...)
+		arguments = append(arguments,
+			"/command=runGame",
+			fmt.Sprintf("/gameId=%d", game.ProductID),
+			fmt.Sprintf("/path=%s", cli.Quote(game.InstallationPath)),
+		)
+
+		results = append(results, &Shortcut{
+			ID:             shortcuts.GenerateID(game.Title, executable),
+			Program:        "gog-galaxy",
+			Name:           game.Title,
+			Description:    "Game from GOG",
+			StartDirectory: cli.Quote(filepath.Dir(executable)),
+			Executable:     cli.Quote(executable),
+			LaunchOptions:  strings.Join(arguments, " "),
+			RelativePath:   fmt.Sprintf("gog/%d", game.ProductID),
+			Tags:           []string{l.String()},
+		})
+	}
+
+	return results
+}
+
+// Add shortcut to the library
+// Games are managed by the launcher itself, nothing to do here
+func (l *Library) Add(shortcut *Shortcut) error {
+	return nil
+}
+
+// Update shortcut on library
+func (l *Library) Update(shortcut *Shortcut, overwriteAssets bool) error {
+	return nil
+}
+
+// Remove shortcut from the library
+func (l *Library) Remove(shortcut *Shortcut) error {
+	return nil
+}
@@ -0,0 +1,220 @@
+package heroic
+
+import (
+	"path/filepath"
+	"slices"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+)
+
+// Game struct
+type Game struct {
+	Runner      string `json:"runner"`
+	AppName     string `json:"appName"`
+	Title       string `json:"title"`
+	InstallPath string `json:"installPath"`
+	ArtIcon     string `json:"artIcon"`
+	ArtLogo     string `json:"artLogo"`
+	ArtSquare   string `json:"artSquare"`
+	ArtCover    string `json:"artCover"`
+	ArtHero     string `json:"artHero"`
+}
+
+// Store game info struct
+// Heroic caches the same structure for every runner
+type storeGame struct {
+	AppName       string `json:"app_name"`
+	Title         string `json:"title"`
+	ArtIcon       string `json:"art_icon"`
+	ArtLogo       string `json:"art_logo"`
+	ArtSquare     string `json:"art_square"`
+	ArtCover      string `json:"art_cover"`
+	ArtBackground string `json:"art_background"`
+}
+
+// Retrieve store name of runner
+func StoreName(runner string) string {
+	switch runner {
+	case "legendary":
+		return "Epic Games"
+	case "gog":
+		return "GOG"
+	case "nile":
+		return "Amazon Games"
+	}
+
+	return runner
+}
+
+// Retrieve possible Heroic configuration folders
+// Native and Flatpak installations are supported on Linux
+func GetConfigPaths() []string {
+
+	paths := []string{}
+	if cli.IsLinux() {
+		paths = append(paths,
+			fs.ExpandPath("$VAR/com.heroicgameslauncher.hgl/config/heroic"),
+			fs.ExpandPath("$CONFIG/heroic"),
+		)
+	} else {
+		paths = append(paths, fs.ExpandPath("$CONFIG/heroic"))
+	}
+
+	return paths
+}
+
+// Read store cache of runner with game titles and art
+func readStore(configPath string, runner string) (map[string]*storeGame, error) {
+
+	result := map[string]*storeGame{}
+
+	var store struct {
+		Library []*storeGame `json:"library"`
+		Games   []*storeGame `json:"games"`
+	}
+
+	file := filepath.Join(configPath, "store_cache", runner+"_library.json")
+	err := fs.ReadJSON(file, &store)
+	if err != nil {
+		return result, err
+	}
+
+	for _, game := range append(store.Library, store.Games...) {
+		result[game.AppName] = game
+	}
+
+	return result, nil
+}
+
+// Read installed games of Legendary runner (Epic Games)
+func readLegendary(configPath string) (map[string]string, error) {
+
+	result := map[string]string{}
+	installed := map[string]struct {
+		AppName     string `json:"app_name"`
+		InstallPath string `json:"install_path"`
+	}{}
+
+	file := filepath.Join(configPath, "legendaryConfig", "legendary", "installed.json")
+	err := fs.ReadJSON(file, &installed)
+	if err != nil {
+		return result, err
+	}
+
+	for appName, game := range installed {
+		result[appName] = game.InstallPath
+	}
+
+	return result, nil
+}
+
+// Read installed games of GOG runner
+func readGOG(configPath string) (map[string]string, error) {
+
+	result := map[string]string{}
+	var installed struct {
+		Installed []struct {
+			AppName     string `json:"appName"`
+			InstallPath string `json:"install_path"`
+		} `json:"installed"`
+	}
+
+	file := filepath.Join(configPath, "gog_store", "installed.json")
+	err := fs.ReadJSON(file, &installed)
+	if err != nil {
+		return result, err
+	}
+
+	for _, game := range installed.Installed {
+		result[game.AppName] = game.InstallPath
+	}
+
+	return result, nil
+}
+
+// Read installed games of Nile runner (Amazon Games)
+func readNile(configPath string) (map[string]string, error) {
+
+	result := map[string]string{}
+	installed := []struct {
+		ID   string `json:"id"`
+		Path string `json:"path"`
+	}{}
+
+	file := filepath.Join(configPath, "nile_config", "nile", "installed.json")
+	err := fs.ReadJSON(file, &installed)
+	if err != nil {
+		return result, err
+	}
+
+	for _, game := range installed {
+		result[game.ID] = game.Path
+	}
+
+	return result, nil
+}
+
+// Retrieve installed games from every runner on given configuration folder
+func GetInstalledGames(configPath string) ([]*Game, error) {
+
+	games := []*Game{}
+	readers := map[string]func(string) (map[string]string, error){
+		"legendary": readLegendary,
+		"gog":       readGOG,
+		"nile":      readNile,
+	}
+
+	for _, runner := range []string{"legendary", "gog", "nile"} {
+
+		installed, err := readers[runner](configPath)
+		if err != nil {
+			return games, err
+		} else if len(installed) == 0 {
+			continue
+		}
+
+		store, err := readStore(configPath, runner)
+		if err != nil {
+			return games, err
+		}
+
+		for appName, installPath := range installed {
+			game := &Game{
+				Runner:      runner,
+				AppName:     appName,
+				Title:       appName,
+				InstallPath: installPath,
+			}
+
+			// Fill title and art from store cache when available
+			if info, ok := store[appName]; ok {
+				if info.Title != "" {
+					game.Title = info.Title
+				}
+				game.ArtIcon = info.ArtIcon
+				game.ArtLogo = info.ArtLogo
+				game.ArtSquare = info.ArtSquare
+				game.ArtCover = info.ArtCover
+				game.ArtHero = info.ArtBackground
+				if game.ArtHero == "" {
+					game.ArtHero = info.ArtCover
+				}
+			}
+
+			games = append(games, game)
+		}
+	}
+
+	// Keep consistent order between runs
+	slices.SortFunc(games, func(a *Game, b *Game) int {
+		if a.Runner+a.AppName < b.Runner+b.AppName {
+			return -1
+		} else if a.Runner+a.AppName > b.Runner+b.AppName {
+			return 1
+		}
+		return 0
+	})
+
+	return games, nil
+}
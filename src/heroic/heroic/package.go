@@ -0,0 +1,31 @@
+package heroic
+
+import (
+	"fmt"
+
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+	"github.com/mateussouzaweb/nicedeck/src/packaging"
+	"github.com/mateussouzaweb/nicedeck/src/packaging/linux"
+	"github.com/mateussouzaweb/nicedeck/src/packaging/macos"
+	"github.com/mateussouzaweb/nicedeck/src/packaging/windows"
+)
+
+// Retrieve Heroic package
+func GetPackage() packaging.Package {
+	return packaging.Best(&linux.Flatpak{
+		AppID:     "com.heroicgameslauncher.hgl",
+		Namespace: "system",
+		Overrides: []string{
+			fmt.Sprintf("--filesystem=%s", fs.ExpandPath("$GAMES")),
+		},
+		Arguments: packaging.NoArguments(),
+	}, &macos.Homebrew{
+		AppID:     "heroic",
+		Launcher:  "/Applications/Heroic.app",
+		Arguments: packaging.NoArguments(),
+	}, &windows.WinGet{
+		AppID:     "HeroicGamesLauncher.HeroicGamesLauncher",
+		Launcher:  "$PROGRAMS/Heroic/Heroic.exe",
+		Arguments: packaging.NoArguments(),
+	})
+}
@@ -0,0 +1,136 @@
+package heroic
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+	"github.com/mateussouzaweb/nicedeck/src/heroic/heroic"
+	"github.com/mateussouzaweb/nicedeck/src/shortcuts"
+)
+
+// Shortcut alias
+type Shortcut = shortcuts.Shortcut
+
+// Library struct
+type Library struct {
+	DatabasePath string  `json:"databasePath"`
+	Games        []*Game `json:"games"`
+}
+
+// String representation of the library
+func (l *Library) String() string {
+	return "Heroic"
+}
+
+// Operation mode of the library
+func (l *Library) Mode() string {
+	return "IMPORT"
+}
+
+// Init library
+func (l *Library) Init(databasePath string) error {
+	l.DatabasePath = databasePath
+	return nil
+}
+
+// Load library
+func (l *Library) Load() error {
+
+	// Reset and fill basic information
+	l.Games = make([]*Game, 0)
+
+	// Read installed games from every configuration folder
+	// Native and Flatpak installations are detected by their folders
+	// Same game can appear on multiple folders, keep only the first one
+	known := map[string]bool{}
+	for _, configPath := range GetConfigPaths() {
+		exist, err := fs.DirectoryExist(configPath)
+		if err != nil {
+			return err
+		} else if !exist {
+			continue
+		}
+
+		games, err := GetInstalledGames(configPath)
+		if err != nil {
+			return err
+		}
+
+		for _, game := range games {
+			key := game.Runner + "/" + game.AppName
+			if !known[key] {
+				known[key] = true
+				l.Games = append(l.Games, game)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Save library
+func (l *Library) Save() error {
+
+	// Save database state to file
+	err := fs.WriteJSON(l.DatabasePath, l)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Export shortcuts to internal format
+// Installed games launch through Heroic protocol
+func (l *Library) Export() []*Shortcut {
+
+	results := make([]*Shortcut, 0)
+	heroicPackage := heroic.GetPackage()
+	executable := heroicPackage.Executable()
+
+	for _, game := range l.Games {
+		launch := fmt.Sprintf("heroic://launch/%s/%s", game.Runner, game.AppName)
+		arguments := append([]string{}, heroicPackage.Args()...)
+		arguments = append(arguments, "--no-gui", cli.Quote(launch))
+
+		// Launch URL is included in ID, since the executable is always Heroic
+		// Same title from different runners must have different IDs
+		results = append(results, &Shortcut{
+			ID:             shortcuts.GenerateID(game.Title, executable+" "+launch),
+			Program:        "heroic-games",
+			Name:           game.Title,
+			Description:    fmt.Sprintf("Game from %s via Heroic", StoreName(game.Runner)),
+			StartDirectory: cli.Quote(filepath.Dir(executable)),
+			Executable:     cli.Quote(executable),
+			LaunchOptions:  strings.Join(arguments, " "),
+			RelativePath:   game.Runner + "/" + game.AppName,
+			IconPath:       game.ArtIcon,
+			LogoPath:       game.ArtLogo,
+			CoverPath:      game.ArtSquare,
+			BannerPath:     game.ArtCover,
+			HeroPath:       game.ArtHero,
+			Tags:           []string{l.String(), StoreName(game.Runner)},
+		})
+	}
+
+	return results
+}
+
+// Add shortcut to the library
+// Games are managed by Heroic itself, nothing to do here
+func (l *Library) Add(shortcut *Shortcut) error {
+	return nil
+}
+
+// Update shortcut on library
+func (l *Library) Update(shortcut *Shortcut, overwriteAssets bool) error {
+	return nil
+}
+
+// Remove shortcut from the library
+func (l *Library) Remove(shortcut *Shortcut) error {
+	return nil
+}
@@ -50,6 +50,12 @@ func Init() error {
 		cli.SetEnv("START_MENU", fs.ExpandPath("$CONFIG/Microsoft/Windows/Start Menu/Programs"), true)
 	}
 
+	// Portable mode sets paths relative to the portable root
+	err = initPortable()
+	if err != nil {
+		return err
+	}
+
 	// Expose environment variables for internal usage
 	cli.SetEnv("GAMES", fs.ExpandPath("$HOME/Games"), false)
 	cli.SetEnv("APPLICATIONS", fs.ExpandPath("$GAMES/Applications"), false)
@@ -66,6 +72,10 @@ func Init() error {
 	cli.Printf(cli.ColorNotice, "- Home: %s\n", cli.GetEnv("HOME", ""))
 	cli.Printf(cli.ColorNotice, "- Config: %s\n", cli.GetEnv("CONFIG", ""))
 	cli.Printf(cli.ColorNotice, "- Cache: %s\n", cli.GetEnv("CACHE", ""))
+	if cli.GetEnv("PORTABLE", "") != "" {
+		cli.Printf(cli.ColorNotice, "- Portable: %s\n", cli.GetEnv("PORTABLE", ""))
+	}
+
 	cli.Printf(cli.ColorNotice, "- Games: %s\n", cli.GetEnv("GAMES", ""))
 	cli.Printf(cli.ColorNotice, "- Applications: %s\n", cli.GetEnv("APPLICATIONS", ""))
 	cli.Printf(cli.ColorNotice, "- Emulators: %s\n", cli.GetEnv("EMULATORS", ""))
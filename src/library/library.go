@@ -2,12 +2,17 @@ package library
 
 import (
 	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/mateussouzaweb/nicedeck/src/cli"
 	"github.com/mateussouzaweb/nicedeck/src/desktop"
+	"github.com/mateussouzaweb/nicedeck/src/epic"
 	"github.com/mateussouzaweb/nicedeck/src/esde"
 	"github.com/mateussouzaweb/nicedeck/src/fs"
+	"github.com/mateussouzaweb/nicedeck/src/gog"
+	"github.com/mateussouzaweb/nicedeck/src/heroic"
+	"github.com/mateussouzaweb/nicedeck/src/lutris"
 	"github.com/mateussouzaweb/nicedeck/src/shortcuts"
 	"github.com/mateussouzaweb/nicedeck/src/steam"
 )
@@ -24,6 +29,10 @@ type Diff struct {
 }
 
 // Synchronizable interface
+// Mode can be FULL, PARTIAL or IMPORT:
+// - FULL libraries submit and receive every entry
+// - PARTIAL libraries only receive entries
+// - IMPORT libraries only submit entries tagged with the library name
 type Synchronizable interface {
 	String() string
 	Mode() string
@@ -40,9 +49,10 @@ var Shortcuts = &shortcuts.Library{}
 var Steam = &steam.Library{}
 var ESDE = &esde.Library{}
 var Desktop = &desktop.Library{}
-
-// var EpicGames = &epic.Library{}
-// var GOG = &gog.Library{}
+var Heroic = &heroic.Library{}
+var Lutris = &lutris.Library{}
+var EpicGames = &epic.Library{}
+var GOG = &gog.Library{}
 
 // Load library from config path
 func Load() error {
@@ -53,6 +63,10 @@ func Load() error {
 	steamConfigPath := filepath.Join(configPath, "steam.json")
 	esdeConfigPath := filepath.Join(configPath, "esde.json")
 	desktopConfigPath := filepath.Join(configPath, "desktop.json")
+	heroicConfigPath := filepath.Join(configPath, "heroic.json")
+	lutrisConfigPath := filepath.Join(configPath, "lutris.json")
+	epicConfigPath := filepath.Join(configPath, "epic.json")
+	gogConfigPath := filepath.Join(configPath, "gog.json")
 
 	// Init shortcuts library
 	err := Shortcuts.Init(shortcutsConfigPath)
@@ -78,6 +92,30 @@ func Load() error {
 		return err
 	}
 
+	// Init Heroic library
+	err = Heroic.Init(heroicConfigPath)
+	if err != nil {
+		return err
+	}
+
+	// Init Lutris library
+	err = Lutris.Init(lutrisConfigPath)
+	if err != nil {
+		return err
+	}
+
+	// Init Epic Games library
+	err = EpicGames.Init(epicConfigPath)
+	if err != nil {
+		return err
+	}
+
+	// Init GOG library
+	err = GOG.Init(gogConfigPath)
+	if err != nil {
+		return err
+	}
+
 	// Load shortcuts
 	err = Shortcuts.Load()
 	if err != nil {
@@ -104,12 +142,11 @@ func Save() error {
 
 	// Perform synchronization to additional libraries after saving main library
 	// This happens only on this context as one-way sync
+	// Import libraries are not included because they do not receive changes
 	libraries := make([]Synchronizable, 0)
 	libraries = append(libraries, Steam)
 	libraries = append(libraries, ESDE)
 	libraries = append(libraries, Desktop)
-	// libraries = append(libraries, EpicGames)
-	// libraries = append(libraries, GOG)
 
 	for _, library := range libraries {
 
@@ -174,6 +211,19 @@ func Fill(list []*Shortcut) []*Shortcut {
 	return list
 }
 
+// Filter shortcuts that contains the given tag
+func Filter(list []*Shortcut, tag string) []*Shortcut {
+
+	results := make([]*Shortcut, 0)
+	for _, shortcut := range list {
+		if slices.Contains(shortcut.Tags, tag) {
+			results = append(results, shortcut)
+		}
+	}
+
+	return results
+}
+
 // Compare two shortcut lists and return the differences
 func Compare(current []*Shortcut, compare []*Shortcut) Diff {
 
@@ -267,6 +317,19 @@ func Compare(current []*Shortcut, compare []*Shortcut) Diff {
 	}
 }
 
+// Configure import options of the desktop library
+func ConfigureDesktopImport(options *desktop.Import) error {
+
+	err := Desktop.Load()
+	if err != nil {
+		return err
+	}
+
+	Desktop.Configure(options)
+
+	return Desktop.Save()
+}
+
 // Sync libraries to add, update or remove entries
 func Sync() error {
 
@@ -274,8 +337,10 @@ func Sync() error {
 	libraries = append(libraries, Steam)
 	libraries = append(libraries, ESDE)
 	libraries = append(libraries, Desktop)
-	// libraries = append(libraries, EpicGames)
-	// libraries = append(libraries, GOG)
+	libraries = append(libraries, Heroic)
+	libraries = append(libraries, Lutris)
+	libraries = append(libraries, EpicGames)
+	libraries = append(libraries, GOG)
 
 	// Perform synchronization process to main shortcuts library
 	// Please note that shortcuts library is already loaded
@@ -299,11 +364,22 @@ func Sync() error {
 		cli.Debug("Synchronizing %s to library\n", library.String())
 
 		// Compare library with main shortcuts library
+		// Import libraries are compared only with their own entries
+		current := Shortcuts.All()
 		exported := Fill(library.Export())
-		diff := Compare(Shortcuts.All(), exported)
+		if library.Mode() == "IMPORT" {
+			current = Filter(current, library.String())
+			exported = Filter(exported, library.String())
+		}
+
+		diff := Compare(current, exported)
 
 		// Apply differences to main shortcuts library
+		// Entries already present without the library tag were detached by the user
 		for _, shortcut := range diff.Added {
+			if Shortcuts.Get(shortcut.ID).ID == shortcut.ID {
+				continue
+			}
 			err := Shortcuts.Add(shortcut)
 			if err != nil {
 				return err
@@ -0,0 +1,148 @@
+package library
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+)
+
+// Name of the marker file that enables portable mode
+const portableMarker = "nicedeck.portable"
+
+// Portable struct
+// Portable settings are read from the marker file, which can be empty
+// Paths are relative to the folder of the marker file when not absolute
+type Portable struct {
+	Games        string `json:"games"`
+	Applications string `json:"applications"`
+	Emulators    string `json:"emulators"`
+	BIOS         string `json:"bios"`
+	ROMs         string `json:"roms"`
+	State        string `json:"state"`
+}
+
+// Retrieve root folders of mounted removable media
+func MediaRoots() []string {
+
+	patterns := []string{}
+	if cli.IsLinux() {
+		patterns = append(patterns, "/run/media/*/*", "/run/media/*", "/media/*/*", "/mnt/*")
+	} else if cli.IsMacOS() {
+		patterns = append(patterns, "/Volumes/*")
+	} else if cli.IsWindows() {
+		for letter := 'C'; letter <= 'Z'; letter++ {
+			patterns = append(patterns, string(letter)+`:\`)
+		}
+	}
+
+	roots := []string{}
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			continue
+		}
+		roots = append(roots, matches...)
+	}
+
+	return roots
+}
+
+// Find folder of portable marker file
+// Marker is searched next to the executable, then on removable media roots
+func findPortableRoot() (string, error) {
+
+	folders := []string{}
+	executable, err := os.Executable()
+	if err == nil {
+		folders = append(folders, filepath.Dir(executable))
+	}
+
+	folders = append(folders, MediaRoots()...)
+	for _, folder := range folders {
+		exist, err := fs.FileExist(filepath.Join(folder, portableMarker))
+		if err != nil {
+			return "", err
+		} else if exist {
+			return folder, nil
+		}
+	}
+
+	return "", nil
+}
+
+// Read portable settings from marker file in given root
+func readPortable(root string) (*Portable, error) {
+
+	portable := &Portable{}
+	content, err := os.ReadFile(filepath.Join(root, portableMarker))
+	if err != nil {
+		return portable, err
+	}
+
+	if strings.TrimSpace(string(content)) != "" {
+		err = json.Unmarshal(content, portable)
+		if err != nil {
+			return portable, err
+		}
+	}
+
+	return portable, nil
+}
+
+// Set environment paths for portable mode when enabled
+// Explicit environment variables are kept, like on regular mode
+func initPortable() error {
+
+	root := os.Getenv("PORTABLE")
+	if root == "" {
+		found, err := findPortableRoot()
+		if err != nil {
+			return err
+		} else if found == "" {
+			return nil
+		}
+		root = found
+	}
+
+	portable, err := readPortable(root)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// Resolve path relative to the portable root
+	resolve := func(path string, fallback string) string {
+		if path == "" {
+			path = fallback
+		}
+		path = fs.ExpandPath(path)
+		if filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(root, path)
+	}
+
+	cli.SetEnv("PORTABLE", root, true)
+	cli.SetEnv("GAMES", resolve(portable.Games, "Games"), false)
+
+	if portable.Applications != "" {
+		cli.SetEnv("APPLICATIONS", resolve(portable.Applications, ""), false)
+	}
+	if portable.Emulators != "" {
+		cli.SetEnv("EMULATORS", resolve(portable.Emulators, ""), false)
+	}
+	if portable.BIOS != "" {
+		cli.SetEnv("BIOS", resolve(portable.BIOS, ""), false)
+	}
+	if portable.ROMs != "" {
+		cli.SetEnv("ROMS", resolve(portable.ROMs, ""), false)
+	}
+	if portable.State != "" {
+		cli.SetEnv("STATE", resolve(portable.State, ""), false)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package lutris
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+	"github.com/mateussouzaweb/nicedeck/src/lutris/lutris"
+	"github.com/mateussouzaweb/nicedeck/src/sqlite"
+)
+
+// Game struct
+type Game struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Slug       string `json:"slug"`
+	Runner     string `json:"runner"`
+	Platform   string `json:"platform"`
+	Directory  string `json:"directory"`
+	Executable string `json:"executable"`
+	Launcher   string `json:"launcher"`
+	IconPath   string `json:"iconPath"`
+	CoverPath  string `json:"coverPath"`
+	BannerPath string `json:"bannerPath"`
+}
+
+// Read simple two levels YAML file into section.key values
+// Lutris game configs only need this subset of YAML
+func readConfig(path string) (map[string]string, error) {
+
+	result := map[string]string{}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return result, err
+	}
+
+	defer func() {
+		errors.Join(err, file.Close())
+	}()
+
+	section := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "-") {
+			continue
+		}
+
+		key, value, found := strings.Cut(trimmed, ":")
+		if !found {
+			continue
+		}
+
+		value = strings.Trim(strings.TrimSpace(value), "'\"")
+		if !strings.HasPrefix(line, " ") {
+			section = key
+			continue
+		}
+
+		result[section+"."+key] = value
+	}
+
+	return result, scanner.Err()
+}
+
+// Retrieve file path if exists
+func findFile(path string) string {
+	if exist, _ := fs.FileExist(path); exist {
+		return path
+	}
+	return ""
+}
+
+// Retrieve installed games from Lutris installation
+func GetInstalledGames(installation *lutris.Installation) ([]*Game, error) {
+
+	games := []*Game{}
+
+	databasePath := filepath.Join(installation.DataPath, "pga.db")
+	exist, err := fs.FileExist(databasePath)
+	if err != nil {
+		return games, err
+	} else if !exist {
+		return games, nil
+	}
+
+	database, err := sqlite.Open(databasePath)
+	if err != nil {
+		return games, err
+	}
+
+	rows, err := database.Table("games")
+	if err != nil {
+		return games, err
+	}
+
+	text := func(value any) string {
+		if result, ok := value.(string); ok {
+			return result
+		}
+		return ""
+	}
+
+	for _, row := range rows {
+
+		// Skip games that are not installed
+		if installed, ok := row["installed"].(int64); !ok || installed != 1 {
+			continue
+		}
+
+		id, _ := row["id"].(int64)
+		game := &Game{
+			ID:         id,
+			Name:       text(row["name"]),
+			Slug:       text(row["slug"]),
+			Runner:     text(row["runner"]),
+			Platform:   text(row["platform"]),
+			Directory:  text(row["directory"]),
+			Executable: text(row["executable"]),
+			Launcher:   installation.Package.Executable(),
+		}
+
+		// Read game executable from per-game config when available
+		configPath := text(row["configpath"])
+		for _, folder := range installation.ConfigPaths {
+			if configPath == "" {
+				break
+			}
+
+			file := findFile(filepath.Join(folder, configPath+".yml"))
+			if file == "" {
+				continue
+			}
+
+			config, err := readConfig(file)
+			if err != nil {
+				return games, err
+			}
+
+			if config["game.exe"] != "" {
+				game.Executable = config["game.exe"]
+			} else if config["game.main_file"] != "" {
+				game.Executable = config["game.main_file"]
+			}
+			if game.Directory == "" {
+				game.Directory = config["game.working_dir"]
+			}
+			break
+		}
+
+		// Use artwork downloaded by Lutris when available
+		game.IconPath = findFile(filepath.Join(installation.IconsPath, "lutris_"+game.Slug+".png"))
+		game.CoverPath = findFile(filepath.Join(installation.DataPath, "coverart", game.Slug+".jpg"))
+		game.BannerPath = findFile(filepath.Join(installation.DataPath, "banners", game.Slug+".jpg"))
+
+		games = append(games, game)
+	}
+
+	return games, nil
+}
@@ -0,0 +1,234 @@
+package lutris
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+	"github.com/mateussouzaweb/nicedeck/src/lutris/lutris"
+	"github.com/mateussouzaweb/nicedeck/src/scraper"
+	"github.com/mateussouzaweb/nicedeck/src/shortcuts"
+)
+
+// Shortcut alias
+type Shortcut = shortcuts.Shortcut
+
+// Artwork struct
+// Timestamp is the time of scrape, empty artwork is kept to avoid new requests
+type Artwork struct {
+	IconPath   string `json:"iconPath"`
+	LogoPath   string `json:"logoPath"`
+	CoverPath  string `json:"coverPath"`
+	BannerPath string `json:"bannerPath"`
+	HeroPath   string `json:"heroPath"`
+	Timestamp  int64  `json:"timestamp"`
+}
+
+// Check if artwork has no images
+func (a *Artwork) Empty() bool {
+	return a.IconPath == "" &&
+		a.LogoPath == "" &&
+		a.CoverPath == "" &&
+		a.BannerPath == "" &&
+		a.HeroPath == ""
+}
+
+// Time to wait before trying to scrape games without artwork again
+const retryInterval = 7 * 24 * time.Hour
+
+// Library struct
+// Scraped artwork is cached by game slug to avoid repeated requests
+type Library struct {
+	DatabasePath string              `json:"databasePath"`
+	Artwork      map[string]*Artwork `json:"artwork"`
+	Games        []*Game             `json:"-"`
+}
+
+// String representation of the library
+func (l *Library) String() string {
+	return "Lutris"
+}
+
+// Operation mode of the library
+func (l *Library) Mode() string {
+	return "IMPORT"
+}
+
+// Init library
+func (l *Library) Init(databasePath string) error {
+	l.DatabasePath = databasePath
+	return nil
+}
+
+// Load library
+func (l *Library) Load() error {
+
+	// Reset and fill basic information
+	l.Artwork = make(map[string]*Artwork, 0)
+	l.Games = make([]*Game, 0)
+
+	// Lutris is only available on Linux
+	if !cli.IsLinux() {
+		return nil
+	}
+
+	// Read database file content
+	err := fs.ReadJSON(l.DatabasePath, &l)
+	if err != nil {
+		return err
+	}
+	if l.Artwork == nil {
+		l.Artwork = make(map[string]*Artwork, 0)
+	}
+
+	// Read installed games from every installed package
+	// Same game can appear on multiple installations, keep only the first one
+	known := map[string]bool{}
+	for _, installation := range lutris.GetInstallations() {
+		installed, err := installation.Package.Installed()
+		if err != nil {
+			return err
+		} else if !installed {
+			continue
+		}
+
+		games, err := GetInstalledGames(installation)
+		if err != nil {
+			return err
+		}
+
+		found := map[string]bool{}
+		for _, game := range games {
+			if !known[game.Slug] {
+				found[game.Slug] = true
+				l.Games = append(l.Games, game)
+			}
+		}
+		for slug := range found {
+			known[slug] = true
+		}
+	}
+
+	// Scrape missing artwork of new games
+	// Games without artwork are only scraped again after retry interval
+	now := time.Now().UTC()
+	for _, game := range l.Games {
+		if cached, ok := l.Artwork[game.Slug]; ok && cached != nil {
+			retry := time.Unix(cached.Timestamp, 0).Add(retryInterval)
+			if !cached.Empty() || now.Before(retry) {
+				continue
+			}
+		}
+
+		artwork := &Artwork{Timestamp: now.Unix()}
+		l.Artwork[game.Slug] = artwork
+
+		cli.Debug("Scraping artwork for Lutris game: %s\n", game.Name)
+		options := scraper.ToOptions(game.Name, true, true, true, true, true)
+		scrape, err := scraper.Scrape(options)
+		if err != nil {
+			cli.Printf(cli.ColorWarn, "Could not scrape artwork for %s: %s\n", game.Name, err)
+			continue
+		}
+
+		if len(scrape.IconURLs) > 0 {
+			artwork.IconPath = scrape.IconURLs[0]
+		}
+		if len(scrape.LogoURLs) > 0 {
+			artwork.LogoPath = scrape.LogoURLs[0]
+		}
+		if len(scrape.CoverURLs) > 0 {
+			artwork.CoverPath = scrape.CoverURLs[0]
+		}
+		if len(scrape.BannerURLs) > 0 {
+			artwork.BannerPath = scrape.BannerURLs[0]
+		}
+		if len(scrape.HeroURLs) > 0 {
+			artwork.HeroPath = scrape.HeroURLs[0]
+		}
+	}
+
+	return nil
+}
+
+// Save library
+func (l *Library) Save() error {
+
+	// Save database state to file
+	err := fs.WriteJSON(l.DatabasePath, l)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Export shortcuts to internal format
+// Installed games launch through Lutris protocol
+// Artwork from Lutris is prioritized over the scraped artwork
+func (l *Library) Export() []*Shortcut {
+
+	results := make([]*Shortcut, 0)
+	for _, game := range l.Games {
+
+		artwork := &Artwork{}
+		if cached, ok := l.Artwork[game.Slug]; ok && cached != nil {
+			artwork = cached
+		}
+
+		iconPath := game.IconPath
+		if iconPath == "" {
+			iconPath = artwork.IconPath
+		}
+		coverPath := game.CoverPath
+		if coverPath == "" {
+			coverPath = artwork.CoverPath
+		}
+		bannerPath := game.BannerPath
+		if bannerPath == "" {
+			bannerPath = artwork.BannerPath
+		}
+
+		// Launch URL is included in ID, since the launcher is always Lutris
+		// Same name from different games must have different IDs
+		launch := fmt.Sprintf("lutris:rungameid/%d", game.ID)
+		arguments := []string{launch}
+		results = append(results, &Shortcut{
+			ID:             shortcuts.GenerateID(game.Name, game.Launcher+" "+launch),
+			Program:        "lutris",
+			Name:           game.Name,
+			Description:    fmt.Sprintf("Game from Lutris (%s)", game.Runner),
+			StartDirectory: cli.Quote(filepath.Dir(game.Launcher)),
+			Executable:     cli.Quote(game.Launcher),
+			LaunchOptions:  strings.Join(arguments, " "),
+			RelativePath:   "lutris/" + game.Slug,
+			IconPath:       iconPath,
+			LogoPath:       artwork.LogoPath,
+			CoverPath:      coverPath,
+			BannerPath:     bannerPath,
+			HeroPath:       artwork.HeroPath,
+			Tags:           []string{l.String()},
+		})
+	}
+
+	return results
+}
+
+// Add shortcut to the library
+// Games are managed by Lutris itself, nothing to do here
+func (l *Library) Add(shortcut *Shortcut) error {
+	return nil
+}
+
+// Update shortcut on library
+func (l *Library) Update(shortcut *Shortcut, overwriteAssets bool) error {
+	return nil
+}
+
+// Remove shortcut from the library
+func (l *Library) Remove(shortcut *Shortcut) error {
+	return nil
+}
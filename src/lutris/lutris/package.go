@@ -0,0 +1,47 @@
+package lutris
+
+import (
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+	"github.com/mateussouzaweb/nicedeck/src/packaging"
+	"github.com/mateussouzaweb/nicedeck/src/packaging/linux"
+)
+
+// Installation struct
+// Each Lutris package keeps games on its own data and config folders
+type Installation struct {
+	Package     packaging.Package `json:"-"`
+	DataPath    string            `json:"dataPath"`
+	ConfigPaths []string          `json:"configPaths"`
+	IconsPath   string            `json:"iconsPath"`
+}
+
+// Retrieve possible Lutris installations
+// Native and Flatpak installations are supported on Linux
+func GetInstallations() []*Installation {
+	return []*Installation{{
+		Package: &linux.Flatpak{
+			AppID:     "net.lutris.Lutris",
+			Namespace: "system",
+			Overrides: []string{},
+			Arguments: packaging.NoArguments(),
+		},
+		DataPath: fs.ExpandPath("$VAR/net.lutris.Lutris/data/lutris"),
+		ConfigPaths: []string{
+			fs.ExpandPath("$VAR/net.lutris.Lutris/data/lutris/games"),
+			fs.ExpandPath("$VAR/net.lutris.Lutris/config/lutris/games"),
+		},
+		IconsPath: fs.ExpandPath("$VAR/net.lutris.Lutris/data/icons/hicolor/128x128/apps"),
+	}, {
+		Package: &linux.Binary{
+			AppID:     "lutris",
+			Launcher:  "/usr/bin/lutris",
+			Arguments: packaging.NoArguments(),
+		},
+		DataPath: fs.ExpandPath("$SHARE/lutris"),
+		ConfigPaths: []string{
+			fs.ExpandPath("$SHARE/lutris/games"),
+			fs.ExpandPath("$CONFIG/lutris/games"),
+		},
+		IconsPath: fs.ExpandPath("$SHARE/icons/hicolor/128x128/apps"),
+	}}
+}
@@ -0,0 +1,17 @@
+package management
+
+import (
+	"github.com/mateussouzaweb/nicedeck/src/platforms"
+	"github.com/mateussouzaweb/nicedeck/src/platforms/console"
+)
+
+// Check BIOS files of platforms based on given options
+func CheckBIOS(options *platforms.Options) ([]*console.BIOSReport, error) {
+
+	theOptions, err := console.ToOptions(options.Include, options.Preferences)
+	if err != nil {
+		return []*console.BIOSReport{}, err
+	}
+
+	return console.CheckBIOS(theOptions, true)
+}
@@ -0,0 +1,19 @@
+package management
+
+import (
+	"fmt"
+
+	"github.com/mateussouzaweb/nicedeck/src/platforms/configure"
+)
+
+// Apply or revert recommended configuration of programs with given options
+func ConfigurePrograms(options *configure.Options) error {
+	switch options.Action {
+	case "apply":
+		return configure.Apply(options)
+	case "revert":
+		return configure.Revert(options)
+	}
+
+	return fmt.Errorf("invalid configure action: %s", options.Action)
+}
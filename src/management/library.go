@@ -1,6 +1,9 @@
 package management
 
-import "github.com/mateussouzaweb/nicedeck/src/library"
+import (
+	"github.com/mateussouzaweb/nicedeck/src/desktop"
+	"github.com/mateussouzaweb/nicedeck/src/library"
+)
 
 // Init library by setting environment paths
 func InitLibrary() error {
@@ -21,3 +24,8 @@ func SaveLibrary() error {
 func SyncLibrary() error {
 	return library.Sync()
 }
+
+// Configure import options of the desktop library
+func ConfigureDesktopImport(options *desktop.Import) error {
+	return library.ConfigureDesktopImport(options)
+}
@@ -0,0 +1,139 @@
+package management
+
+import (
+	"os"
+	"slices"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/library"
+	"github.com/mateussouzaweb/nicedeck/src/platforms/console"
+)
+
+// Check removable media of the ROMs root folder
+// When media is absent, ROM shortcuts can be hidden on Steam until it returns
+// Shortcuts hidden by this process are shown again once the media is present
+func CheckROMsMedia(root *console.Root, hide bool) (bool, error) {
+
+	media, err := console.GetMedia()
+	if err != nil {
+		return false, err
+	}
+
+	media, available, err := console.CheckMedia(media, root.Path)
+	if err != nil {
+		return false, err
+	}
+
+	tracked := console.FindMedia(media, root.Path)
+	if tracked == nil {
+		return available, nil
+	}
+
+	if !available {
+		cli.Printf(cli.ColorWarn, "Media for ROMs at %s is not present, keeping existing shortcuts.\n", root.Path)
+	}
+
+	// Update visibility of ROM shortcuts on Steam when required
+	hideShortcuts := !available && hide
+	showShortcuts := available && len(tracked.Hidden) > 0
+
+	if hideShortcuts || showShortcuts {
+		err = library.Steam.Load()
+		if err != nil {
+			return available, err
+		}
+
+		if hideShortcuts {
+			IDs := []string{}
+			for _, shortcut := range GetShortcuts() {
+				if !slices.Contains(shortcut.Tags, "ROM") {
+					continue
+				}
+				if ID, _ := console.SplitRelativePath(shortcut.RelativePath); ID == root.ID {
+					IDs = append(IDs, shortcut.ID)
+				}
+			}
+
+			changed := library.Steam.SetHidden(IDs, true)
+			for _, ID := range changed {
+				if !slices.Contains(tracked.Hidden, ID) {
+					tracked.Hidden = append(tracked.Hidden, ID)
+				}
+			}
+
+			if len(changed) > 0 {
+				cli.Printf(cli.ColorNotice, "ROM shortcuts hidden on Steam until media is present.\n")
+			}
+		} else {
+			library.Steam.SetHidden(tracked.Hidden, false)
+			tracked.Hidden = []string{}
+			cli.Printf(cli.ColorNotice, "Media for ROMs at %s is present again, showing shortcuts on Steam.\n", root.Path)
+		}
+
+		err = library.Steam.Save()
+		if err != nil {
+			return available, err
+		}
+	}
+
+	err = console.SetMedia(media)
+	if err != nil {
+		return available, err
+	}
+
+	return available, nil
+}
+
+// Show ROM shortcuts again on Steam for tracked media that is present
+func RestoreROMsMedia() error {
+
+	media, err := console.GetMedia()
+	if err != nil {
+		return err
+	}
+
+	roots, err := console.GetAllRoots()
+	if err != nil {
+		return err
+	}
+
+	for _, root := range roots {
+		tracked := console.FindMedia(media, root.Path)
+		if tracked == nil || len(tracked.Hidden) == 0 {
+			continue
+		}
+
+		_, err := CheckROMsMedia(root, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Retrieve ROMs roots with present media and IDs of absent roots
+// Missing additional roots are considered absent instead of failing
+func availableROMsRoots(roots []*console.Root, hide bool) ([]*console.Root, []string, error) {
+
+	available := []*console.Root{}
+	absent := []string{}
+
+	for _, root := range roots {
+		present, err := CheckROMsMedia(root, hide)
+		if os.IsNotExist(err) && root.ID != "" {
+			cli.Printf(cli.ColorWarn, "ROMs root %s not found at: %s\n", root.Label, root.Path)
+			present = false
+		} else if err != nil {
+			return available, absent, err
+		}
+
+		if present {
+			available = append(available, root)
+		} else {
+			absent = append(absent, root.ID)
+		}
+	}
+
+	return available, absent, nil
+}
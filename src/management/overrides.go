@@ -0,0 +1,85 @@
+package management
+
+import (
+	"path/filepath"
+	"slices"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/platforms/console"
+	"github.com/mateussouzaweb/nicedeck/src/retroarch"
+)
+
+// Retrieve list of ROM overrides
+func GetROMOverrides() ([]*console.Override, error) {
+	return console.GetOverrides()
+}
+
+// Add or update ROM override and refresh the existing ROM shortcut
+func SetROMOverride(override *console.Override) error {
+
+	err := console.SetOverride(override)
+	if err != nil {
+		return err
+	}
+
+	return refreshROMShortcut(override.RelativePath)
+}
+
+// Remove ROM override and refresh the existing ROM shortcut
+func RemoveROMOverride(relativePath string) error {
+
+	relativePath = console.NormalizeOverridePath(relativePath)
+	err := console.RemoveOverride(relativePath)
+	if err != nil {
+		return err
+	}
+
+	return refreshROMShortcut(relativePath)
+}
+
+// Refresh launch details of the ROM shortcut with given relative path
+// Nothing happens if the ROM was not processed into a shortcut yet
+func refreshROMShortcut(relativePath string) error {
+
+	for _, shortcut := range GetShortcuts() {
+
+		// Check if shortcut is managed ROM of the given path
+		if !slices.Contains(shortcut.Tags, "ROM") {
+			continue
+		}
+		if shortcut.RelativePath != relativePath {
+			continue
+		}
+
+		theOptions, err := console.ToOptions([]string{}, []string{})
+		if err != nil {
+			return err
+		}
+
+		path := console.ToAbsolutePath(theOptions.Roots, relativePath)
+		rom, err := console.ParseROM(path, theOptions)
+		if err != nil {
+			return err
+		} else if rom.Executable == "" {
+			cli.Printf(cli.ColorWarn, "Could not find emulator for ROM: %s\n", relativePath)
+			return nil
+		}
+
+		// Make sure core of multi-system emulator is installed
+		if rom.Core != "" {
+			err := retroarch.InstallCore(rom.Core)
+			if err != nil {
+				cli.Printf(cli.ColorWarn, "Could not install RetroArch core %s: %s\n", rom.Core, err)
+			}
+		}
+
+		shortcut.Program = rom.Program
+		shortcut.StartDirectory = cli.Quote(filepath.Dir(rom.Executable))
+		shortcut.Executable = cli.Quote(rom.Executable)
+		shortcut.LaunchOptions = rom.LaunchOptions
+
+		return UpdateShortcut(shortcut, false)
+	}
+
+	return nil
+}
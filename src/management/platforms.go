@@ -11,9 +11,19 @@ import (
 	"github.com/mateussouzaweb/nicedeck/src/platforms"
 	"github.com/mateussouzaweb/nicedeck/src/platforms/console"
 	"github.com/mateussouzaweb/nicedeck/src/platforms/native"
+	"github.com/mateussouzaweb/nicedeck/src/retroarch"
 	"github.com/mateussouzaweb/nicedeck/src/shortcuts"
 )
 
+// Generate shortcut ID for console ROM
+// ROMs from additional roots include the root ID to avoid collisions
+func romShortcutID(rom *console.ROM) string {
+	if rom.Root == "" {
+		return shortcuts.GenerateID(rom.Name, rom.Executable)
+	}
+	return shortcuts.GenerateID(rom.Name, rom.Executable+"@"+rom.Root)
+}
+
 // Parse and process shortcut with given path
 func ProcessPlatformShortcut(name string, path string, options *platforms.Options) (*shortcuts.Shortcut, error) {
 
@@ -79,7 +89,7 @@ func ProcessPlatformShortcut(name string, path string, options *platforms.Option
 		if rom.Executable != "" {
 			nameFormat = fmt.Sprintf("${NAME} [%s]", rom.Platform)
 			startDirectory := filepath.Dir(rom.Executable)
-			shortcutID := shortcuts.GenerateID(rom.Name, rom.Executable)
+			shortcutID := romShortcutID(rom)
 			shortcut = &shortcuts.Shortcut{
 				ID:             shortcutID,
 				Program:        rom.Program,
@@ -135,6 +145,19 @@ func ProcessPlatformShortcuts(options *platforms.Options) error {
 	// Determine if should include ROMs even if scraper was not able to detect it
 	optionalScraper := slices.Contains(theOptions.Preferences, "optional-scraper")
 
+	// Skip roots while their removable media is absent
+	// This will keep existing shortcuts instead of removing them
+	hideMissing := slices.Contains(theOptions.Preferences, "hide-missing-media")
+	available, absent, err := availableROMsRoots(theOptions.Roots, hideMissing)
+	if err != nil {
+		return err
+	} else if len(available) == 0 {
+		cli.Printf(cli.ColorSuccess, "Process finished!\n")
+		return nil
+	}
+
+	theOptions.Roots = available
+
 	// First, find all existing ROMs path
 	// We read the current list of ROMs from the library
 	existing := []string{}
@@ -150,6 +173,48 @@ func ProcessPlatformShortcuts(options *platforms.Options) error {
 		return err
 	}
 
+	// Warn about platforms with detected ROMs but missing required BIOS
+	// Only presence and size are checked here, hashes are left to bios command
+	detected := []string{}
+	for _, rom := range parsed {
+		if !slices.Contains(detected, rom.Platform) {
+			detected = append(detected, rom.Platform)
+		}
+	}
+
+	if len(detected) > 0 {
+		biosOptions, err := console.ToOptions(detected, theOptions.Preferences)
+		if err != nil {
+			return err
+		}
+
+		reports, err := console.CheckBIOS(biosOptions, false)
+		if err != nil {
+			return err
+		}
+
+		for _, report := range reports {
+			if !report.Ready {
+				cli.Printf(cli.ColorWarn, "Required BIOS for %s is missing or invalid at %s\n", report.Platform, report.Path)
+			}
+		}
+	}
+
+	// Make sure cores of multi-system emulators are installed
+	cores := []string{}
+	for _, rom := range parsed {
+		if rom.Core != "" && !slices.Contains(cores, rom.Core) {
+			cores = append(cores, rom.Core)
+		}
+	}
+
+	for _, core := range cores {
+		err := retroarch.InstallCore(core)
+		if err != nil {
+			cli.Printf(cli.ColorWarn, "Could not install RetroArch core %s: %s\n", core, err)
+		}
+	}
+
 	// Filter ROMs to avoid unnecessary processing
 	filtered := console.FilterROMs(parsed, existing, theOptions)
 	total := len(filtered)
@@ -171,7 +236,7 @@ func ProcessPlatformShortcuts(options *platforms.Options) error {
 
 			// Create shortcut information
 			startDirectory := filepath.Dir(rom.Executable)
-			shortcutID := shortcuts.GenerateID(rom.Name, rom.Executable)
+			shortcutID := romShortcutID(rom)
 			shortcut := &shortcuts.Shortcut{
 				ID:             shortcutID,
 				Program:        rom.Program,
@@ -249,6 +314,11 @@ func ProcessPlatformShortcuts(options *platforms.Options) error {
 			continue
 		}
 
+		// Keep shortcuts of roots with absent media
+		if root, _ := console.SplitRelativePath(shortcut.RelativePath); slices.Contains(absent, root) {
+			continue
+		}
+
 		// Check if the ROM of the shortcut is on the list of parsed ROMs
 		found := false
 		for _, rom := range parsed {
@@ -273,3 +343,96 @@ func ProcessPlatformShortcuts(options *platforms.Options) error {
 	cli.Printf(cli.ColorSuccess, "Process finished!\n")
 	return nil
 }
+
+// Generate health report of ROMs for given platforms
+func GetPlatformsReport(options *platforms.Options) (*platforms.Report, error) {
+
+	report := &platforms.Report{
+		Orphans: []*shortcuts.Shortcut{},
+		Files:   []*console.ROMFile{},
+	}
+
+	theOptions, err := console.ToOptions(options.Include, options.Preferences)
+	if err != nil {
+		return report, err
+	}
+
+	// Report only includes roots with present media
+	available, absent, err := availableROMsRoots(theOptions.Roots, false)
+	if err != nil {
+		return report, err
+	}
+
+	roots := theOptions.Roots
+	theOptions.Roots = available
+
+	// Inspect files and keep only files with issues
+	files, err := console.InspectROMs(theOptions)
+	if err != nil {
+		return report, err
+	}
+
+	for _, file := range files {
+		if file.Status == "valid" {
+			report.Total++
+		} else {
+			report.Files = append(report.Files, file)
+		}
+	}
+
+	// Detect ROM shortcuts pointing to files that no longer exist
+	for _, shortcut := range GetShortcuts() {
+
+		// Check if shortcut is managed ROM
+		if !slices.Contains(shortcut.Tags, "ROM") {
+			continue
+		}
+
+		// Check if shortcut belongs to included platforms
+		if len(options.Include) > 0 {
+			included := slices.ContainsFunc(options.Include, func(platform string) bool {
+				return slices.Contains(shortcut.Tags, platform)
+			})
+			if !included {
+				continue
+			}
+		}
+
+		// Check if shortcut belongs to root with present media
+		root, _ := console.SplitRelativePath(shortcut.RelativePath)
+		if slices.Contains(absent, root) {
+			continue
+		}
+
+		path := console.ToAbsolutePath(roots, shortcut.RelativePath)
+		exist, err := fs.FileExist(path)
+		if err != nil {
+			return report, err
+		} else if exist {
+			continue
+		}
+
+		exist, err = fs.DirectoryExist(path)
+		if err != nil {
+			return report, err
+		} else if !exist {
+			report.Orphans = append(report.Orphans, shortcut)
+		}
+	}
+
+	return report, nil
+}
+
+// Remove orphan ROM shortcuts from given report
+func PruneOrphanShortcuts(report *platforms.Report) error {
+
+	for _, shortcut := range report.Orphans {
+		cli.Printf(cli.ColorNotice, "Removing shortcut for orphan ROM: %s\n", shortcut.RelativePath)
+		err := RemoveShortcut(shortcut)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
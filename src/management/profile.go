@@ -0,0 +1,370 @@
+package management
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+	"github.com/mateussouzaweb/nicedeck/src/library"
+	"github.com/mateussouzaweb/nicedeck/src/packaging"
+	"github.com/mateussouzaweb/nicedeck/src/platforms/configure"
+	"github.com/mateussouzaweb/nicedeck/src/platforms/console"
+	"github.com/mateussouzaweb/nicedeck/src/platforms/state"
+	"github.com/mateussouzaweb/nicedeck/src/programs"
+	"github.com/mateussouzaweb/nicedeck/src/scraper"
+)
+
+// Profile Steam struct
+type ProfileSteam struct {
+	Account string `json:"account"`
+}
+
+// Profile struct
+// Profile declares the desired setup of the machine
+// Sections not declared in the profile are kept untouched when applied
+// Declared programs are installed, while other programs installed by
+// NiceDeck are removed, so an empty list removes every program
+type Profile struct {
+	Programs    []string                 `json:"programs"`
+	Preferences []string                 `json:"preferences"`
+	Platforms   []console.CustomPlatform `json:"platforms"`
+	Emulators   []console.CustomEmulator `json:"emulators"`
+	Roots       []console.Root           `json:"roots"`
+	States      []state.CustomState      `json:"states"`
+	Steam       *ProfileSteam            `json:"steam,omitempty"`
+	Scraper     *scraper.Settings        `json:"scraper"`
+}
+
+// Profile plan struct
+// Plan contains the changes required to match the profile
+type ProfilePlan struct {
+	Install   []string `json:"install"`
+	Remove    []string `json:"remove"`
+	Configure []string `json:"configure"`
+	Settings  []string `json:"settings"`
+}
+
+// Check if plan has no changes to apply
+func (p *ProfilePlan) Empty() bool {
+	return len(p.Install) == 0 &&
+		len(p.Remove) == 0 &&
+		len(p.Configure) == 0 &&
+		len(p.Settings) == 0
+}
+
+// Check if profile file is in YAML format
+func isYAMLProfile(path string) bool {
+	extension := strings.ToLower(filepath.Ext(path))
+	return extension == ".yaml" || extension == ".yml"
+}
+
+// Read profile from given file path
+// Format is detected from extension, files are in JSON format by default
+func ReadProfile(path string) (*Profile, error) {
+
+	exist, err := fs.FileExist(path)
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, fmt.Errorf("profile not found: %s", path)
+	}
+
+	profile := &Profile{}
+	if isYAMLProfile(path) {
+		err = fs.ReadYAML(path, profile)
+	} else {
+		err = fs.ReadJSON(path, profile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read profile %s: %s", path, err)
+	}
+
+	return profile, nil
+}
+
+// Write profile into given file path
+// Format is detected from extension, files are in JSON format by default
+func WriteProfile(path string, profile *Profile) error {
+	if isYAMLProfile(path) {
+		return fs.WriteYAML(path, profile)
+	}
+
+	return fs.WriteJSON(path, profile)
+}
+
+// Retrieve current Steam account, empty when not detected
+func currentSteamAccount() (string, error) {
+
+	err := library.Steam.Load()
+	if err != nil {
+		return "", err
+	}
+
+	return library.Steam.AccountId, nil
+}
+
+// Export current setup as profile
+// Only programs installed by NiceDeck are exported
+func ExportProfile() (*Profile, error) {
+
+	profile := &Profile{
+		Programs:    []string{},
+		Preferences: []string{},
+	}
+
+	versions, err := packaging.GetVersions()
+	if err != nil {
+		return profile, err
+	}
+
+	for _, version := range versions {
+		program, err := programs.GetProgramByID(version.Program)
+		if err != nil {
+			return profile, err
+		}
+		if slices.Contains(program.Flags, "--installed") {
+			profile.Programs = append(profile.Programs, program.ID)
+		}
+	}
+
+	profile.Platforms, err = console.GetCustomPlatforms()
+	if err != nil {
+		return profile, err
+	}
+
+	profile.Emulators, err = console.GetCustomEmulators()
+	if err != nil {
+		return profile, err
+	}
+
+	profile.Roots, err = console.GetRoots()
+	if err != nil {
+		return profile, err
+	}
+
+	profile.States, err = state.GetCustomStates()
+	if err != nil {
+		return profile, err
+	}
+
+	account, err := currentSteamAccount()
+	if err != nil {
+		return profile, err
+	} else if account != "" {
+		profile.Steam = &ProfileSteam{Account: account}
+	}
+
+	profile.Scraper, err = scraper.GetSettings()
+	if err != nil {
+		return profile, err
+	}
+
+	return profile, nil
+}
+
+// Check if both values have the same JSON representation
+func sameJSON(a any, b any) bool {
+	first, errFirst := json.Marshal(a)
+	second, errSecond := json.Marshal(b)
+	return errFirst == nil && errSecond == nil && string(first) == string(second)
+}
+
+// Compare profile with current setup and retrieve changes to apply
+func PlanProfile(profile *Profile) (*ProfilePlan, error) {
+
+	plan := &ProfilePlan{
+		Install:   []string{},
+		Remove:    []string{},
+		Configure: []string{},
+		Settings:  []string{},
+	}
+
+	// Programs in profile are installed or configured when drifted
+	installed := []string{}
+	for _, id := range profile.Programs {
+		program, err := programs.GetProgramByID(id)
+		if err != nil {
+			return plan, err
+		} else if program.ID == "" {
+			return plan, fmt.Errorf("program not found: %s", id)
+		}
+
+		exist, err := program.Package.Installed()
+		if err != nil {
+			return plan, err
+		}
+
+		if !exist {
+			plan.Install = append(plan.Install, program.ID)
+		} else {
+			installed = append(installed, program.ID)
+		}
+	}
+
+	if len(installed) > 0 && !slices.Contains(profile.Preferences, "skip-configure") {
+		options := configure.ToOptions("apply", installed, profile.Preferences)
+		drift, err := configure.Drift(options)
+		if err != nil {
+			return plan, err
+		}
+
+		plan.Configure = append(plan.Configure, drift...)
+	}
+
+	// Programs installed by NiceDeck but missing in profile are removed
+	if profile.Programs != nil {
+		versions, err := packaging.GetVersions()
+		if err != nil {
+			return plan, err
+		}
+
+		for _, version := range versions {
+			if slices.Contains(profile.Programs, version.Program) {
+				continue
+			}
+
+			program, err := programs.GetProgramByID(version.Program)
+			if err != nil {
+				return plan, err
+			}
+			if slices.Contains(program.Flags, "--installed") {
+				plan.Remove = append(plan.Remove, program.ID)
+			}
+		}
+	}
+
+	// Settings are replaced when declared and different
+	if profile.Platforms != nil {
+		current, err := console.GetCustomPlatforms()
+		if err != nil {
+			return plan, err
+		} else if !sameJSON(current, profile.Platforms) {
+			plan.Settings = append(plan.Settings, "platforms")
+		}
+	}
+
+	if profile.Emulators != nil {
+		current, err := console.GetCustomEmulators()
+		if err != nil {
+			return plan, err
+		} else if !sameJSON(current, profile.Emulators) {
+			plan.Settings = append(plan.Settings, "emulators")
+		}
+	}
+
+	if profile.Roots != nil {
+		current, err := console.GetRoots()
+		if err != nil {
+			return plan, err
+		} else if !sameJSON(current, profile.Roots) {
+			plan.Settings = append(plan.Settings, "roots")
+		}
+	}
+
+	if profile.States != nil {
+		current, err := state.GetCustomStates()
+		if err != nil {
+			return plan, err
+		} else if !sameJSON(current, profile.States) {
+			plan.Settings = append(plan.Settings, "states")
+		}
+	}
+
+	if profile.Steam != nil && profile.Steam.Account != "" {
+		current, err := currentSteamAccount()
+		if err != nil {
+			return plan, err
+		} else if current != profile.Steam.Account {
+			plan.Settings = append(plan.Settings, "steam")
+		}
+	}
+
+	if profile.Scraper != nil {
+		current, err := scraper.GetSettings()
+		if err != nil {
+			return plan, err
+		} else if !sameJSON(current, profile.Scraper) {
+			plan.Settings = append(plan.Settings, "scraper")
+		}
+	}
+
+	return plan, nil
+}
+
+// Apply settings of profile listed in plan
+func applyProfileSettings(profile *Profile, plan *ProfilePlan) error {
+
+	for _, setting := range plan.Settings {
+		cli.Printf(cli.ColorNotice, "Updating %s settings...\n", setting)
+
+		var err error
+		switch setting {
+		case "platforms":
+			err = console.SetCustomPlatforms(profile.Platforms)
+		case "emulators":
+			err = console.SetCustomEmulators(profile.Emulators)
+		case "roots":
+			err = console.SetRoots(profile.Roots)
+		case "states":
+			err = state.SetCustomStates(profile.States)
+		case "steam":
+			err = library.Steam.SetAccount(profile.Steam.Account)
+		case "scraper":
+			err = scraper.SetSettings(profile.Scraper)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Apply changes of plan to match the profile
+// Settings are applied first, so installed programs use the new settings
+func ApplyProfile(profile *Profile, plan *ProfilePlan) error {
+
+	err := applyProfileSettings(profile, plan)
+	if err != nil {
+		return err
+	}
+
+	// Steam shortcuts of the new account are filled from library
+	if slices.Contains(plan.Settings, "steam") {
+		err = SyncLibrary()
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(plan.Install) > 0 {
+		options := programs.ToOptions(plan.Install, profile.Preferences)
+		err = InstallPrograms(options)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(plan.Configure) > 0 {
+		options := configure.ToOptions("apply", plan.Configure, profile.Preferences)
+		err = ConfigurePrograms(options)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(plan.Remove) > 0 {
+		options := programs.ToOptions(plan.Remove, profile.Preferences)
+		err = RemovePrograms(options)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -6,32 +6,117 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"sync"
 
 	"github.com/mateussouzaweb/nicedeck/src/cli"
 	"github.com/mateussouzaweb/nicedeck/src/fs"
+	"github.com/mateussouzaweb/nicedeck/src/packaging"
+	"github.com/mateussouzaweb/nicedeck/src/platforms/configure"
 	"github.com/mateussouzaweb/nicedeck/src/programs"
 	"github.com/mateussouzaweb/nicedeck/src/shortcuts"
 )
 
-// Install programs with given options
-func InstallPrograms(options *programs.Options) error {
+// Number of concurrent downloads when installing many programs
+const concurrentDownloads = 3
 
-	for _, id := range options.Programs {
+// Download sources of programs concurrently ahead of install
+// Failures are only reported, since install retries the download
+func prefetchPrograms(list []*packaging.Program) {
 
-		program, err := programs.GetProgramByID(id)
-		if err != nil {
-			return err
+	var wait sync.WaitGroup
+	queue := make(chan struct{}, concurrentDownloads)
+
+	for _, program := range list {
+		if slices.Contains(program.Flags, "--system") {
+			continue
 		}
 
-		// Program not found
-		if program.ID == "" {
-			return fmt.Errorf("program not found: %s", id)
+		wait.Add(1)
+		go func(program *packaging.Program) {
+			defer wait.Done()
+
+			queue <- struct{}{}
+			defer func() { <-queue }()
+
+			err := packaging.Prefetch(program.Package)
+			if err != nil {
+				cli.Printf(cli.ColorWarn, "Could not download %s in advance: %s\n", program.Name, err)
+			}
+		}(program)
+	}
+
+	wait.Wait()
+}
+
+// Print notices about recommended and conflicting programs
+// Conflicts are checked against installed programs and the install list
+func checkProgramsRelations(catalog []*packaging.Program, list []*packaging.Program) {
+
+	for _, program := range list {
+
+		missing := []string{}
+		for _, id := range program.Recommends {
+			recommended := slices.IndexFunc(catalog, func(item *packaging.Program) bool {
+				return item.ID == id
+			})
+			if recommended < 0 || slices.Contains(catalog[recommended].Flags, "--installed") {
+				continue
+			}
+			if slices.Contains(list, catalog[recommended]) {
+				continue
+			}
+			missing = append(missing, catalog[recommended].Name)
 		}
 
-		// Program not available
-		if !program.Package.Available() {
-			return fmt.Errorf("program is not available to install: %s", id)
+		if len(missing) > 0 {
+			cli.Printf(cli.ColorNotice, "%s works best with: %s\n", program.Name, strings.Join(missing, ", "))
+		}
+
+		for _, other := range catalog {
+			if other == program || !program.ConflictsWith(other) {
+				continue
+			}
+
+			index := slices.Index(list, other)
+			if index >= 0 && index < slices.Index(list, program) {
+				continue // Pair already reported
+			}
+			if index >= 0 || slices.Contains(other.Flags, "--installed") {
+				cli.Printf(cli.ColorWarn, "Warning: %s conflicts with %s, consider keeping only one of them.\n", program.Name, other.Name)
+			}
+		}
+	}
+}
+
+// Install programs with given options
+// Required programs are installed first when missing
+func InstallPrograms(options *programs.Options) error {
+
+	catalog, err := programs.GetPrograms()
+	if err != nil {
+		return err
+	}
+
+	list, err := packaging.Resolve(catalog, options.Programs)
+	if err != nil {
+		return err
+	}
+
+	for _, program := range list {
+		if !slices.Contains(options.Programs, program.ID) {
+			cli.Printf(cli.ColorNotice, "Including %s as requirement.\n", program.Name)
 		}
+	}
+
+	checkProgramsRelations(catalog, list)
+
+	// Download sources concurrently before the sequential install
+	if len(list) > 1 {
+		cli.Printf(cli.ColorNotice, "Downloading programs...\n")
+		prefetchPrograms(list)
+	}
+
+	for _, program := range list {
 
 		// Make sure required folders exist
 		if len(program.Folders) > 0 {
@@ -53,7 +138,13 @@ func InstallPrograms(options *programs.Options) error {
 		// Run program installation when possible
 		if canInstallPackage {
 			cli.Printf(cli.ColorNotice, "Installing %s...\n", program.Name)
-			err = program.Package.Install()
+			err := program.Package.Install()
+			if err != nil {
+				return err
+			}
+
+			// Record installed version to allow update checks
+			err = packaging.SetVersion(program.ID, program.Package)
 			if err != nil {
 				return err
 			}
@@ -61,6 +152,15 @@ func InstallPrograms(options *programs.Options) error {
 			cli.Printf(cli.ColorSuccess, "%s installed!\n", program.Name)
 		}
 
+		// Apply recommended configuration unless user opted out
+		if !slices.Contains(options.Preferences, "skip-configure") {
+			configureOptions := configure.ToOptions("apply", []string{program.ID}, options.Preferences)
+			err := ConfigurePrograms(configureOptions)
+			if err != nil {
+				return err
+			}
+		}
+
 		cli.Printf(cli.ColorNotice, "Creating shortcut for %s...\n", program.Name)
 
 		// Add desktop flag or tag to control automatic shortcut creation
@@ -102,7 +202,7 @@ func InstallPrograms(options *programs.Options) error {
 		}
 
 		// Add to shortcuts list
-		err = SetShortcut(shortcut, false)
+		err := SetShortcut(shortcut, false)
 		if err != nil {
 			return err
 		}
@@ -167,6 +267,11 @@ func RemovePrograms(options *programs.Options) error {
 				return err
 			}
 
+			err = packaging.RemoveVersion(program.ID)
+			if err != nil {
+				return err
+			}
+
 			cli.Printf(cli.ColorSuccess, "%s removed!\n", program.Name)
 		}
 
@@ -174,3 +279,68 @@ func RemovePrograms(options *programs.Options) error {
 
 	return nil
 }
+
+// Restore previous version of installed program
+func RollbackProgram(id string) error {
+
+	program, err := programs.GetProgramByID(id)
+	if err != nil {
+		return err
+	}
+
+	// Program not found
+	if program.ID == "" {
+		return fmt.Errorf("program not found: %s", id)
+	}
+
+	// Only packages installed from sources keep previous versions
+	reversible, ok := program.Package.(packaging.Reversible)
+	if !ok {
+		return fmt.Errorf("rollback is not supported for %s package: %s", program.Package.Runtime(), id)
+	}
+
+	cli.Printf(cli.ColorNotice, "Restoring previous version of %s...\n", program.Name)
+	err = reversible.Rollback()
+	if err != nil {
+		return err
+	}
+
+	err = packaging.RollbackVersion(program.ID)
+	if err != nil {
+		return err
+	}
+
+	cli.Printf(cli.ColorSuccess, "%s previous version restored!\n", program.Name)
+
+	return nil
+}
+
+// Check installed programs for available updates
+// Programs provided by the system are not checked
+func CheckProgramsUpdates() ([]*packaging.Update, error) {
+
+	updates := []*packaging.Update{}
+
+	list, err := programs.GetPrograms()
+	if err != nil {
+		return updates, err
+	}
+
+	for _, program := range list {
+		if !slices.Contains(program.Flags, "--installed") {
+			continue
+		}
+		if slices.Contains(program.Flags, "--system") {
+			continue
+		}
+
+		update, err := packaging.CheckUpdate(program.ID, program.Package)
+		if err != nil {
+			cli.Printf(cli.ColorWarn, "Could not check updates for %s: %s\n", program.Name, err)
+		}
+
+		updates = append(updates, update)
+	}
+
+	return updates, nil
+}
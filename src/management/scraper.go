@@ -7,14 +7,27 @@ import (
 
 // Scrape information such as images from given app or game name
 func ScrapeData(options *scraper.Options) (*scraper.ScrapeResult, error) {
+
+	settings, err := scraper.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	settings.Apply()
 	return scraper.Scrape(options)
 }
 
 // Scrape data from shortcut and return if was found
 func ScrapeShortcut(shortcut *shortcuts.Shortcut) (bool, error) {
 
-	// Scrape additional ROM information
-	options := scraper.ToOptions(shortcut.Name, true, true, true, true, true)
+	// Scrape additional ROM information based on user settings
+	settings, err := scraper.GetSettings()
+	if err != nil {
+		return false, err
+	}
+
+	settings.Apply()
+	options := settings.ToOptions(shortcut.Name)
 	scrape, err := scraper.Scrape(options)
 	if err != nil {
 		return false, err
@@ -11,3 +11,8 @@ func SyncState(options *state.Options) error {
 func GetState(options *state.Options) ([]*state.Synchronizable, error) {
 	return state.GetSynchronizables(options)
 }
+
+// Return diff of synchronizable items based on given options
+func GetStateDiff(options *state.Options) ([]*state.Diff, error) {
+	return state.GetDiff(options)
+}
@@ -0,0 +1,94 @@
+package packaging
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Dependent interface for packages requiring other programs to run
+type Dependent interface {
+	Requirements() []string
+}
+
+// Retrieve programs required by program, including package requirements
+func (p *Program) Requirements() []string {
+
+	requirements := slices.Clone(p.Requires)
+	if dependent, ok := p.Package.(Dependent); ok {
+		for _, requirement := range dependent.Requirements() {
+			if !slices.Contains(requirements, requirement) {
+				requirements = append(requirements, requirement)
+			}
+		}
+	}
+
+	return requirements
+}
+
+// Check if program conflicts with another program in any direction
+func (p *Program) ConflictsWith(other *Program) bool {
+	return slices.Contains(p.Conflicts, other.ID) || slices.Contains(other.Conflicts, p.ID)
+}
+
+// Find program with given ID in list
+func findProgram(list []*Program, id string) *Program {
+	for _, program := range list {
+		if program.ID == id {
+			return program
+		}
+	}
+	return nil
+}
+
+// Resolve programs to install from catalog of available programs
+// Missing requirements are included, and each program comes after its requirements
+// Installed requirements are skipped, since installed programs are flagged
+func Resolve(catalog []*Program, ids []string) ([]*Program, error) {
+
+	result := []*Program{}
+	visiting := map[string]bool{}
+
+	var visit func(id string, requiredBy *Program) error
+	visit = func(id string, requiredBy *Program) error {
+
+		program := findProgram(catalog, id)
+		if program == nil && requiredBy != nil {
+			return fmt.Errorf("%s requires %s, which is not available", requiredBy.Name, id)
+		} else if program == nil {
+			return fmt.Errorf("program not found: %s", id)
+		}
+
+		if visiting[program.ID] {
+			return fmt.Errorf("circular requirement detected on program: %s", program.ID)
+		}
+		if slices.Contains(result, program) {
+			return nil
+		}
+
+		// Requirements already installed are not installed again
+		if requiredBy != nil && slices.Contains(program.Flags, "--installed") {
+			return nil
+		}
+
+		visiting[program.ID] = true
+		for _, requirement := range program.Requirements() {
+			err := visit(requirement, program)
+			if err != nil {
+				return err
+			}
+		}
+		visiting[program.ID] = false
+
+		result = append(result, program)
+		return nil
+	}
+
+	for _, id := range ids {
+		err := visit(id, nil)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
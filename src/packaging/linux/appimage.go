@@ -30,21 +30,17 @@ func (a *AppImage) Available() bool {
 // Install package
 func (a *AppImage) Install() error {
 
-	// Download from source
+	// Download from source into staged folder
 	if a.Source != nil {
-		err := a.Source.Download(a)
-		if err != nil {
-			return err
-		}
-
-		// Make sure is executable
-		if installed, _ := a.Installed(); installed {
-			executable := a.Executable()
-			err := os.Chmod(executable, 0775)
+		return packaging.Stage(a, &a.Launcher, func() error {
+			err := a.Source.Download(a)
 			if err != nil {
 				return err
 			}
-		}
+
+			// Make sure is executable
+			return os.Chmod(a.Executable(), 0775)
+		})
 	}
 
 	return nil
@@ -60,6 +56,12 @@ func (a *AppImage) Remove() error {
 		return err
 	}
 
+	// Remove staged and previous versions
+	err = packaging.RemoveStaged(a)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -89,3 +91,13 @@ func (a *AppImage) Alias() string {
 func (a *AppImage) Args() []string {
 	return a.Arguments.Shortcut
 }
+
+// Restore previous version of package
+func (a *AppImage) Rollback() error {
+	return packaging.RollbackFolder(a)
+}
+
+// Return package download source
+func (a *AppImage) GetSource() *packaging.Source {
+	return a.Source
+}
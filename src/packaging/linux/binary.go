@@ -38,21 +38,17 @@ func (b *Binary) Available() bool {
 // Install package
 func (b *Binary) Install() error {
 
-	// Download from source
+	// Download from source into staged folder
 	if b.Source != nil {
-		err := b.Source.Download(b)
-		if err != nil {
-			return err
-		}
-
-		// Make sure is executable
-		if installed, _ := b.Installed(); installed {
-			executable := b.Executable()
-			err := os.Chmod(executable, 0775)
+		return packaging.Stage(b, &b.Launcher, func() error {
+			err := b.Source.Download(b)
 			if err != nil {
 				return err
 			}
-		}
+
+			// Make sure is executable
+			return os.Chmod(b.Executable(), 0775)
+		})
 	}
 
 	return nil
@@ -68,6 +64,12 @@ func (b *Binary) Remove() error {
 		return err
 	}
 
+	// Remove staged and previous versions
+	err = packaging.RemoveStaged(b)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -97,3 +99,13 @@ func (b *Binary) Alias() string {
 func (b *Binary) Args() []string {
 	return b.Arguments.Shortcut
 }
+
+// Restore previous version of package
+func (b *Binary) Rollback() error {
+	return packaging.RollbackFolder(b)
+}
+
+// Return package download source
+func (b *Binary) GetSource() *packaging.Source {
+	return b.Source
+}
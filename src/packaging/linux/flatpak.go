@@ -126,3 +126,25 @@ func (f *Flatpak) Alias() string {
 func (f *Flatpak) Args() []string {
 	return f.Arguments.Shortcut
 }
+
+// Check if package has an update available
+func (f *Flatpak) Outdated() (bool, error) {
+	script := fmt.Sprintf(
+		`flatpak remote-ls --updates --app --columns=application --%s`,
+		f.Namespace,
+	)
+
+	command := cli.Command(script)
+	output, err := cli.Capture(command)
+	if err != nil {
+		return false, err
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) == f.AppID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
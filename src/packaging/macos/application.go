@@ -29,12 +29,11 @@ func (a *Application) Available() bool {
 // Install package
 func (a *Application) Install() error {
 
-	// Download from source
+	// Download from source into staged folder
 	if a.Source != nil {
-		err := a.Source.Download(a)
-		if err != nil {
-			return err
-		}
+		return packaging.Stage(a, &a.Launcher, func() error {
+			return a.Source.Download(a)
+		})
 	}
 
 	return nil
@@ -50,6 +49,12 @@ func (a *Application) Remove() error {
 		return err
 	}
 
+	// Remove staged and previous versions
+	err = packaging.RemoveStaged(a)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -81,3 +86,13 @@ func (a *Application) Alias() string {
 func (a *Application) Args() []string {
 	return a.Arguments.Shortcut
 }
+
+// Restore previous version of package
+func (a *Application) Rollback() error {
+	return packaging.RollbackFolder(a)
+}
+
+// Return package download source
+func (a *Application) GetSource() *packaging.Source {
+	return a.Source
+}
@@ -88,3 +88,22 @@ func (h *Homebrew) Alias() string {
 func (h *Homebrew) Args() []string {
 	return h.Arguments.Shortcut
 }
+
+// Check if package has an update available
+func (h *Homebrew) Outdated() (bool, error) {
+	// Named casks would make the command fail when outdated
+	// So the full list of outdated casks is checked instead
+	command := cli.Command(`brew outdated --cask --quiet`)
+	output, err := cli.Capture(command)
+	if err != nil {
+		return false, err
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) == h.AppID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
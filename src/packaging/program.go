@@ -1,6 +1,9 @@
 package packaging
 
 // Program struct
+// Requires lists programs that must be installed first
+// Recommends lists programs that complement the program
+// Conflicts lists programs that overlap with the program
 type Program struct {
 	ID          string   `json:"id"`
 	Name        string   `json:"name"`
@@ -15,5 +18,8 @@ type Program struct {
 	CoverURL    string   `json:"coverUrl"`
 	BannerURL   string   `json:"bannerUrl"`
 	HeroURL     string   `json:"heroUrl"`
+	Requires    []string `json:"requires,omitempty"`
+	Recommends  []string `json:"recommends,omitempty"`
+	Conflicts   []string `json:"conflicts,omitempty"`
 	Package     Package  `json:"-"`
 }
@@ -122,6 +122,16 @@ func (p *Proton) RealPath(path string) string {
 	return path
 }
 
+// Retrieve real path for given path of any package
+// Paths of Proton packages are resolved inside the prefix drive
+func ResolvePath(target packaging.Package, path string) string {
+	if runtime, ok := target.(*Proton); ok {
+		return runtime.RealPath(path)
+	}
+
+	return fs.ExpandPath(path)
+}
+
 // Retrieve virtual path for given path
 func (p *Proton) VirtualPath(path string) string {
 	path = p.RealPath(path)
@@ -407,3 +417,13 @@ func (p *Proton) Args() []string {
 	arguments = append(arguments, p.Arguments.Shortcut...)
 	return arguments
 }
+
+// Return package download source
+func (p *Proton) GetSource() *packaging.Source {
+	return p.Source
+}
+
+// Retrieve programs required by package
+func (p *Proton) Requirements() []string {
+	return []string{"steam"}
+}
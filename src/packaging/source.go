@@ -1,6 +1,7 @@
 package packaging
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,19 +13,39 @@ import (
 
 type Resolver func() (string, error)
 
+// Prefetcher interface for packages that download source to custom location
+type Prefetcher interface {
+	Prefetch() error
+}
+
+// Source struct
+// Checksum is the expected SHA-256 of the downloaded file
+// When not declared, checksum is read from the checksum URL if available
 type Source struct {
-	URL         string   `json:"url"`
-	Destination string   `json:"destination"`
-	Format      string   `json:"format"`
-	Resolver    Resolver `json:"-"`
+	URL         string     `json:"url"`
+	Destination string     `json:"destination"`
+	Format      string     `json:"format"`
+	Checksum    string     `json:"checksum"`
+	ChecksumURL string     `json:"checksumUrl"`
+	Signature   *Signature `json:"signature"`
+	Digest      string     `json:"digest"`
+	Resolver    Resolver   `json:"-"`
 }
 
-// Download content and extract it source into target
-func (s *Source) Download(target Package) error {
+// Retrieve latest download URL without downloading the content
+func (s *Source) Latest() (string, error) {
+	if s.Resolver != nil {
+		return s.Resolver()
+	}
+
+	return s.URL, nil
+}
 
-	// URL can be retrieved from:
-	// - Direct link in URL field
-	// - Custom method when resolver is defined
+// Resolve download URL when not defined yet
+// URL can be retrieved from:
+// - Direct link in URL field
+// - Custom method when resolver is defined
+func (s *Source) Resolve() error {
 	if s.URL == "" && s.Resolver != nil {
 		url, err := s.Resolver()
 		s.URL = url
@@ -33,13 +54,76 @@ func (s *Source) Download(target Package) error {
 		}
 	}
 
+	return nil
+}
+
+// Retrieve path of the downloaded file for the given destination
+func (s *Source) DownloadPath(destination string) string {
+	switch s.Format {
+	case "zip", "tar.gz", "tar.xz", "7z":
+		parentFolder := filepath.Dir(destination)
+		targetFile := strings.TrimPrefix(destination, parentFolder)
+		targetName := strings.TrimSuffix(targetFile, filepath.Ext(targetFile))
+		archiveName := fmt.Sprintf("%s.%s", targetName, s.Format)
+		return filepath.Join(parentFolder, archiveName)
+	case "dmg":
+		dmgFile := strings.TrimSuffix(destination, filepath.Ext(destination))
+		return fmt.Sprintf("%s.dmg", dmgFile)
+	}
+
+	return destination
+}
+
+// Download content ahead of install into the temporary file
+// Install later resumes from the temporary file, which is already complete
+func (s *Source) Prefetch(destination string) error {
+
+	err := s.Resolve()
+	if err != nil {
+		return err
+	}
+
+	if s.Destination != "" {
+		destination = s.Destination
+	}
+
+	tmpDestination := fmt.Sprintf("%s.tmp", s.DownloadPath(destination))
+	return fs.ResumeDownload(s.URL, tmpDestination)
+}
+
+// Download source of package ahead of install when available
+func Prefetch(target Package) error {
+
+	if prefetcher, ok := target.(Prefetcher); ok {
+		return prefetcher.Prefetch()
+	}
+
+	sourced, ok := target.(Sourced)
+	if !ok || sourced.GetSource() == nil {
+		return nil
+	}
+
+	return sourced.GetSource().Prefetch(target.Executable())
+}
+
+// Download content and extract it source into target
+func (s *Source) Download(target Package) error {
+
+	err := s.Resolve()
+	if err != nil {
+		return err
+	}
+
 	cli.Printf(cli.ColorNotice, "Downloading: %s\n", s.URL)
+	// Destination from target is not kept, since target may be staged
 	if s.Destination == "" {
 		s.Destination = target.Executable()
+		defer func() {
+			s.Destination = ""
+		}()
 	}
 
 	// Download based on format
-	var err error
 	switch s.Format {
 	case "file":
 		err = s.FromFile()
@@ -64,44 +148,50 @@ func (s *Source) Download(target Package) error {
 }
 
 // Safe download to destination file to avoid collision
+// Perform safe download operation with the following process
+// - Download or resume new file on .tmp
+// - Remove existing .old file
+// - Rename existing file to .old
+// - Rename .tmp to final destination
+// Partial .tmp file is kept on failures to resume on the next attempt
 func (s *Source) SafeDownload(url string, destination string) error {
 
-	// Check if destination file exists
-	// When not exists, just download the new file
-	exist, err := fs.FileExist(destination)
-	if err != nil {
-		return err
-	} else if !exist {
-		return fs.DownloadFile(url, destination, false)
-	}
-
-	// Perform safe download operation with the following process
-	// - Remove existing .tmp
-	// - Download new file to .tmp
-	// - Remove existing .old file
-	// - Rename existing file to .old
-	// - Rename .tmp to final destination
 	tmpDestination := fmt.Sprintf("%s.tmp", destination)
 	oldDestination := fmt.Sprintf("%s.old", destination)
 
-	err = fs.RemoveFile(tmpDestination)
+	err := fs.ResumeDownload(url, tmpDestination)
 	if err != nil {
 		return err
 	}
 
-	err = fs.DownloadFile(url, tmpDestination, false)
+	// Keep existing file when download is invalid
+	err = s.Verify(tmpDestination)
 	if err != nil {
-		return err
+		return errors.Join(
+			err,
+			fs.RemoveFile(tmpDestination),
+			fs.FinishDownload(tmpDestination),
+		)
 	}
 
-	err = fs.RemoveFile(oldDestination)
+	err = fs.FinishDownload(tmpDestination)
 	if err != nil {
 		return err
 	}
 
-	err = fs.MoveFile(destination, oldDestination)
+	exist, err := fs.FileExist(destination)
 	if err != nil {
 		return err
+	} else if exist {
+		err = fs.RemoveFile(oldDestination)
+		if err != nil {
+			return err
+		}
+
+		err = fs.MoveFile(destination, oldDestination)
+		if err != nil {
+			return err
+		}
 	}
 
 	err = fs.MoveFile(tmpDestination, destination)
@@ -109,6 +199,12 @@ func (s *Source) SafeDownload(url string, destination string) error {
 		return err
 	}
 
+	// Keep digest of downloaded file to identify the installed release
+	s.Digest, err = fs.Checksum(destination)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -131,9 +227,7 @@ func (s *Source) FromArchive() error {
 	parentFolder := filepath.Dir(s.Destination)
 	extractFolder := filepath.Join(parentFolder, ".extract")
 	targetFile := strings.TrimPrefix(s.Destination, parentFolder)
-	targetName := strings.TrimSuffix(targetFile, filepath.Ext(targetFile))
-	archiveName := fmt.Sprintf("%s.%s", targetName, s.Format)
-	archiveFile := filepath.Join(parentFolder, archiveName)
+	archiveFile := s.DownloadPath(s.Destination)
 
 	// Download file
 	err := s.SafeDownload(s.URL, archiveFile)
@@ -171,9 +265,9 @@ func (s *Source) FromArchive() error {
 	}
 
 	// Copy files from extract to final destination
-	// Use copy to avoid losing files
+	// Use copy to avoid losing files, keeping links of the release
 	copyFrom := filepath.Dir(realPath)
-	err = fs.CopyDirectory(copyFrom, parentFolder)
+	err = fs.CopyDirectoryWithLinks(copyFrom, parentFolder)
 	if err != nil {
 		return err
 	}
@@ -197,8 +291,7 @@ func (s *Source) FromArchive() error {
 func (s *Source) FromDMG() error {
 
 	// Download file
-	dmgFile := strings.TrimSuffix(s.Destination, filepath.Ext(s.Destination))
-	dmgFile = fmt.Sprintf("%s.dmg", dmgFile)
+	dmgFile := s.DownloadPath(s.Destination)
 	err := s.SafeDownload(s.URL, dmgFile)
 	if err != nil {
 		return err
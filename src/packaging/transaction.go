@@ -0,0 +1,276 @@
+package packaging
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+)
+
+// Reversible interface for packages able to restore the previous version
+type Reversible interface {
+	Rollback() error
+}
+
+// Retrieve staging folder of package folder
+func stagingFolder(folder string) string {
+	return folder + ".new"
+}
+
+// Retrieve folder with previous version of package folder
+func previousFolder(folder string) string {
+	return folder + ".previous"
+}
+
+// Name of file on previous version folder with the list of files of the
+// release that replaced them, so rollback only swaps release files
+const releaseManifest = ".release.json"
+
+// List files of release on folder, relative to the folder
+// Application bundles are listed as a whole to keep them consistent
+func releaseFiles(folder string) ([]string, error) {
+
+	files := []string{}
+	err := filepath.WalkDir(folder, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if path == folder {
+			return nil
+		}
+
+		relative, err := filepath.Rel(folder, path)
+		if err != nil {
+			return err
+		} else if relative == releaseManifest {
+			return nil
+		}
+
+		if entry.IsDir() {
+			if strings.HasSuffix(entry.Name(), ".app") {
+				files = append(files, relative)
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		files = append(files, relative)
+		return nil
+	})
+
+	return files, err
+}
+
+// Move listed files from source to destination folder
+// Files missing on source are ignored and other files are kept untouched
+func moveFiles(files []string, source string, destination string) error {
+
+	for _, file := range files {
+		from := filepath.Join(source, file)
+		_, err := os.Lstat(from)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		to := filepath.Join(destination, file)
+		err = os.MkdirAll(filepath.Dir(to), 0774)
+		if err != nil {
+			return err
+		}
+
+		err = os.Rename(from, to)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Install package into side-by-side staging folder
+// The launcher is pointed to the empty staging folder while installing, so
+// the installed check only passes with files from the new release
+// Only release files are swapped into package folder, keeping user data,
+// and replaced files are kept as the previous version for rollback
+func Stage(target Package, launcher *string, install func() error) error {
+
+	executable := target.Executable()
+	folder := filepath.Dir(executable)
+	staging := stagingFolder(folder)
+	previous := previousFolder(folder)
+
+	err := fs.RemoveDirectory(staging)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(staging, 0774)
+	if err != nil {
+		return err
+	}
+
+	// Check if current folder has a working version to keep
+	installed, err := target.Installed()
+	if err != nil {
+		return err
+	}
+
+	// Partial download is kept on current folder to resume on next attempt
+	download := ""
+	stagingDownload := ""
+	if sourced, ok := target.(Sourced); ok && sourced.GetSource() != nil {
+		download = sourced.GetSource().DownloadPath(executable)
+		stagingDownload = filepath.Join(staging, filepath.Base(download))
+
+		err = fs.MoveDownload(download+".tmp", stagingDownload+".tmp")
+		if err != nil {
+			return errors.Join(err, fs.RemoveDirectory(staging))
+		}
+	}
+
+	// Install into staging folder
+	original := *launcher
+	*launcher = filepath.Join(staging, filepath.Base(executable))
+
+	err = install()
+	if err == nil {
+		var success bool
+		success, err = target.Installed()
+		if err == nil && !success {
+			err = fmt.Errorf("expected content not detected: %s", target.Executable())
+		}
+	}
+
+	*launcher = original
+
+	// Discard staging folder on failures, current folder remains untouched
+	if err != nil {
+		if download != "" {
+			err = errors.Join(err, fs.MoveDownload(stagingDownload+".tmp", download+".tmp"))
+		}
+		return errors.Join(err, fs.RemoveDirectory(staging))
+	}
+
+	if download != "" {
+		err = fs.RemoveFile(stagingDownload + ".old")
+		if err != nil {
+			return err
+		}
+	}
+
+	files, err := releaseFiles(staging)
+	if err != nil {
+		return err
+	}
+
+	// Keep replaced files as previous version when current version works
+	// Previous version of a folder without working version is not consistent
+	err = fs.RemoveDirectory(previous)
+	if err != nil {
+		return err
+	}
+
+	if installed {
+		err = moveFiles(files, folder, previous)
+		if err == nil {
+			err = fs.WriteJSON(filepath.Join(previous, releaseManifest), files)
+		}
+		if err != nil {
+			return errors.Join(err, moveFiles(files, previous, folder))
+		}
+	}
+
+	// Switch to the staged files or restore replaced files on failure
+	err = moveFiles(files, staging, folder)
+	if err != nil {
+		return errors.Join(
+			err,
+			moveFiles(files, folder, staging),
+			moveFiles(files, previous, folder),
+		)
+	}
+
+	err = fs.RemoveDirectory(staging)
+	if err != nil {
+		return err
+	}
+
+	cli.Debug("Switched %s to the staged version\n", folder)
+
+	return nil
+}
+
+// Restore previous version of package folder
+// Only files of the release are swapped, user data is kept in place
+// Current version becomes the previous one, so rollback can be reverted
+func RollbackFolder(target Package) error {
+
+	folder := filepath.Dir(target.Executable())
+	previous := previousFolder(folder)
+	swap := folder + ".rollback"
+
+	exist, err := fs.DirectoryExist(previous)
+	if err != nil {
+		return err
+	} else if !exist {
+		return fmt.Errorf("no previous version available: %s", folder)
+	}
+
+	// Files of the current release and files of the previous release
+	current := []string{}
+	err = fs.ReadJSON(filepath.Join(previous, releaseManifest), &current)
+	if err != nil {
+		return err
+	}
+
+	restored, err := releaseFiles(previous)
+	if err != nil {
+		return err
+	}
+
+	err = fs.RemoveDirectory(swap)
+	if err != nil {
+		return err
+	}
+
+	err = moveFiles(current, folder, swap)
+	if err != nil {
+		return errors.Join(err, moveFiles(current, swap, folder))
+	}
+
+	err = moveFiles(restored, previous, folder)
+	if err != nil {
+		return errors.Join(
+			err,
+			moveFiles(restored, folder, previous),
+			moveFiles(current, swap, folder),
+		)
+	}
+
+	err = fs.WriteJSON(filepath.Join(swap, releaseManifest), restored)
+	if err != nil {
+		return err
+	}
+
+	err = fs.RemoveDirectory(previous)
+	if err != nil {
+		return err
+	}
+
+	return fs.MoveDirectory(swap, previous)
+}
+
+// Remove staging and previous version folders of package folder
+func RemoveStaged(target Package) error {
+
+	folder := filepath.Dir(target.Executable())
+	return errors.Join(
+		fs.RemoveDirectory(stagingFolder(folder)),
+		fs.RemoveDirectory(previousFolder(folder)),
+	)
+}
@@ -0,0 +1,193 @@
+package packaging
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+)
+
+// Signature struct
+// Type is minisign or gpg and URL is the detached signature file
+// Public key is the minisign key or path to the GPG public key file
+type Signature struct {
+	Type      string `json:"type"`
+	URL       string `json:"url"`
+	PublicKey string `json:"publicKey"`
+}
+
+// Checksum file names searched on releases
+// The ${ASSET} placeholder is replaced with the asset name
+var checksumAssets = []string{
+	"${ASSET}.sha256",
+	"${ASSET}.sha256sum",
+	"${ASSET}.sha256.txt",
+	"SHA256SUMS",
+	"SHA256SUMS.txt",
+	"sha256sums.txt",
+	"sha256sum.txt",
+	"checksums.txt",
+}
+
+// Find checksum file for asset in the list of release assets
+// Returns the index of the checksum asset or -1 when not found
+func FindChecksumAsset(asset string, names []string) int {
+
+	for _, pattern := range checksumAssets {
+		expected := strings.ReplaceAll(pattern, "${ASSET}", asset)
+		for index, name := range names {
+			if strings.EqualFold(name, expected) {
+				return index
+			}
+		}
+	}
+
+	return -1
+}
+
+// Parse expected checksum of asset from checksum file content
+// Supports files with a single hash and the sha256sum format
+func ParseChecksum(content string, asset string) string {
+
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		// Single hash file without file names
+		if len(fields) == 1 && len(lines) == 1 {
+			return strings.ToLower(fields[0])
+		}
+
+		// Binary mode files are prefixed with an asterisk
+		name := strings.TrimPrefix(fields[len(fields)-1], "*")
+		if filepath.Base(name) == asset {
+			return strings.ToLower(fields[0])
+		}
+	}
+
+	return ""
+}
+
+// Verify downloaded file against expected checksum and signature
+// Checksum can be declared on source or read from a checksum file
+func (s *Source) Verify(path string) error {
+
+	expected := s.Checksum
+	asset := AssetName(s.URL)
+
+	// Read expected checksum from checksum file
+	if expected == "" && s.ChecksumURL != "" {
+		checksumFile := path + ".checksum"
+		err := fs.DownloadFile(s.ChecksumURL, checksumFile, true)
+		if err != nil {
+			return err
+		}
+
+		content, err := os.ReadFile(checksumFile)
+		if err != nil {
+			return err
+		}
+
+		err = fs.RemoveFile(checksumFile)
+		if err != nil {
+			return err
+		}
+
+		expected = ParseChecksum(string(content), asset)
+		if expected == "" {
+			return fmt.Errorf("could not find checksum of %s in %s", asset, s.ChecksumURL)
+		}
+	}
+
+	// Compare checksum with the downloaded file
+	if expected != "" {
+		actual, err := fs.Checksum(path)
+		if err != nil {
+			return err
+		}
+
+		if !strings.EqualFold(actual, expected) {
+			return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", asset, expected, actual)
+		}
+
+		cli.Debug("Checksum verified for %s\n", asset)
+	}
+
+	// Verify signature when declared
+	if s.Signature != nil {
+		err := s.Signature.Verify(path)
+		if err != nil {
+			return fmt.Errorf("signature verification failed for %s: %w", asset, err)
+		}
+
+		cli.Debug("Signature verified for %s\n", asset)
+	}
+
+	return nil
+}
+
+// Verify file with detached signature using the respective tool
+func (s *Signature) Verify(path string) error {
+
+	signatureFile := path + ".sig"
+	err := fs.DownloadFile(s.URL, signatureFile, true)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		errors.Join(err, fs.RemoveFile(signatureFile))
+	}()
+
+	switch s.Type {
+	case "minisign":
+		script := fmt.Sprintf(
+			`minisign -V -q -P %s -m %s -x %s`,
+			cli.Quote(s.PublicKey),
+			cli.Quote(path),
+			cli.Quote(signatureFile),
+		)
+
+		return cli.Run(cli.Command(script))
+
+	case "gpg":
+		// Isolated keyring to trust only the declared public key
+		homedir, err := os.MkdirTemp("", "nicedeck-gpg-")
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			errors.Join(err, fs.RemoveDirectory(homedir))
+		}()
+
+		script := fmt.Sprintf(
+			`gpg --batch --quiet --homedir %s --import %s`,
+			cli.Quote(homedir),
+			cli.Quote(fs.ExpandPath(s.PublicKey)),
+		)
+
+		err = cli.Run(cli.Command(script))
+		if err != nil {
+			return err
+		}
+
+		script = fmt.Sprintf(
+			`gpg --batch --quiet --homedir %s --verify %s %s`,
+			cli.Quote(homedir),
+			cli.Quote(signatureFile),
+			cli.Quote(path),
+		)
+
+		return cli.Run(cli.Command(script))
+	}
+
+	return fmt.Errorf("unsupported signature type: %s", s.Type)
+}
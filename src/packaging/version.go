@@ -0,0 +1,242 @@
+package packaging
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"time"
+
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+)
+
+// Sourced interface for packages downloaded from a source
+type Sourced interface {
+	GetSource() *Source
+}
+
+// Updatable interface for packages managed by tools able to detect updates
+type Updatable interface {
+	Outdated() (bool, error)
+}
+
+// Version struct
+// Version records the identity of the installed release
+// Previous version is the release replaced by the last upgrade
+type Version struct {
+	Program   string   `json:"program"`
+	Runtime   string   `json:"runtime"`
+	URL       string   `json:"url"`
+	Asset     string   `json:"asset"`
+	Tag       string   `json:"tag"`
+	Digest    string   `json:"digest"`
+	Timestamp int64    `json:"timestamp"`
+	Previous  *Version `json:"previous,omitempty"`
+}
+
+// Update struct
+// Status is one of outdated, updated or unknown
+type Update struct {
+	Program string `json:"program"`
+	Runtime string `json:"runtime"`
+	Status  string `json:"status"`
+	Current string `json:"current"`
+	Latest  string `json:"latest"`
+}
+
+// Release tag is part of the download URL on most release platforms
+var releaseTagPattern = regexp.MustCompile(`/(?:download|releases)/([^/]+)/`)
+
+// Retrieve versions file path
+func versionsPath() string {
+	return fs.ExpandPath("$APPLICATIONS/NiceDeck/versions.json")
+}
+
+// Retrieve release tag from download URL
+func ReleaseTag(downloadURL string) string {
+	matches := releaseTagPattern.FindStringSubmatch(downloadURL)
+	if len(matches) < 2 {
+		return ""
+	}
+
+	tag, err := url.PathUnescape(matches[1])
+	if err != nil {
+		return matches[1]
+	}
+
+	return tag
+}
+
+// Retrieve asset name from download URL
+func AssetName(downloadURL string) string {
+	parsed, err := url.Parse(downloadURL)
+	if err != nil || parsed.Path == "" {
+		return ""
+	}
+
+	return path.Base(parsed.Path)
+}
+
+// Retrieve recorded versions of installed programs
+func GetVersions() ([]*Version, error) {
+
+	versions := make([]*Version, 0)
+	err := fs.ReadJSON(versionsPath(), &versions)
+	if err != nil {
+		return versions, err
+	}
+
+	return versions, nil
+}
+
+// Retrieve recorded version of program
+func GetVersion(program string) (*Version, error) {
+
+	versions, err := GetVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, version := range versions {
+		if version.Program == program {
+			return version, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Record installed version of program from package details
+func SetVersion(program string, target Package) error {
+
+	versions, err := GetVersions()
+	if err != nil {
+		return err
+	}
+
+	version := &Version{
+		Program:   program,
+		Runtime:   target.Runtime(),
+		Timestamp: time.Now().UTC().Unix(),
+	}
+
+	if sourced, ok := target.(Sourced); ok && sourced.GetSource() != nil {
+		source := sourced.GetSource()
+		version.URL = source.URL
+		version.Asset = AssetName(source.URL)
+		version.Tag = ReleaseTag(source.URL)
+		version.Digest = source.Digest
+	}
+
+	result := []*Version{version}
+	for _, item := range versions {
+		if item.Program != program {
+			result = append(result, item)
+		} else if item.URL != version.URL || item.Digest != version.Digest {
+			item.Previous = nil
+			version.Previous = item
+		} else {
+			version.Previous = item.Previous
+		}
+	}
+
+	return fs.WriteJSON(versionsPath(), result)
+}
+
+// Swap recorded version of program with the previous one after rollback
+func RollbackVersion(program string) error {
+
+	versions, err := GetVersions()
+	if err != nil {
+		return err
+	}
+
+	for index, item := range versions {
+		if item.Program == program && item.Previous != nil {
+			previous := item.Previous
+			item.Previous = nil
+			previous.Previous = item
+			versions[index] = previous
+		}
+	}
+
+	return fs.WriteJSON(versionsPath(), versions)
+}
+
+// Remove recorded version of program
+func RemoveVersion(program string) error {
+
+	versions, err := GetVersions()
+	if err != nil {
+		return err
+	}
+
+	result := []*Version{}
+	for _, item := range versions {
+		if item.Program != program {
+			result = append(result, item)
+		}
+	}
+
+	return fs.WriteJSON(versionsPath(), result)
+}
+
+// Check if installed package of program has an update available
+// Package managers are asked directly, while sourced packages compare the
+// recorded download URL with the latest URL resolved from the source
+func CheckUpdate(program string, target Package) (*Update, error) {
+
+	update := &Update{
+		Program: program,
+		Runtime: target.Runtime(),
+		Status:  "unknown",
+	}
+
+	if updatable, ok := target.(Updatable); ok {
+		outdated, err := updatable.Outdated()
+		if err != nil {
+			return update, err
+		} else if outdated {
+			update.Status = "outdated"
+		} else {
+			update.Status = "updated"
+		}
+
+		return update, nil
+	}
+
+	sourced, ok := target.(Sourced)
+	if !ok || sourced.GetSource() == nil {
+		return update, nil
+	}
+
+	latest, err := sourced.GetSource().Latest()
+	if err != nil {
+		return update, err
+	}
+
+	update.Latest = AssetName(latest)
+	if tag := ReleaseTag(latest); tag != "" {
+		update.Latest = tag
+	}
+
+	// Without a recorded version is not possible to compare
+	version, err := GetVersion(program)
+	if err != nil {
+		return update, err
+	} else if version == nil || version.URL == "" {
+		return update, nil
+	}
+
+	update.Current = version.Asset
+	if version.Tag != "" {
+		update.Current = version.Tag
+	}
+
+	if version.URL == latest {
+		update.Status = "updated"
+	} else {
+		update.Status = "outdated"
+	}
+
+	return update, nil
+}
@@ -1,6 +1,8 @@
 package windows
 
 import (
+	"errors"
+	"fmt"
 	"path/filepath"
 
 	"github.com/mateussouzaweb/nicedeck/src/cli"
@@ -48,10 +50,9 @@ func (e *Executable) Install() error {
 		}
 
 	} else if e.Source != nil {
-		err := e.Source.Download(e)
-		if err != nil {
-			return err
-		}
+		return packaging.Stage(e, &e.Launcher, func() error {
+			return e.Source.Download(e)
+		})
 	}
 
 	// When installer is needed, run installer
@@ -111,12 +112,22 @@ func (e *Executable) Remove() error {
 	// Remove installer file
 	// Because installer is placed in another location
 	if e.Installer != "" {
-		err := fs.RemoveFile(fs.ExpandPath(e.Installer))
+		installer := fs.ExpandPath(e.Installer)
+		err := errors.Join(
+			fs.RemoveFile(installer),
+			fs.RemoveFile(installer+".old"),
+		)
 		if err != nil {
 			return err
 		}
 	}
 
+	// Remove staged and previous versions
+	err = packaging.RemoveStaged(e)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -152,3 +163,66 @@ func (e *Executable) Alias() string {
 func (e *Executable) Args() []string {
 	return e.Arguments.Shortcut
 }
+
+// Restore previous version of package
+// Installer based packages run the previous installer kept on upgrade
+func (e *Executable) Rollback() error {
+	if e.Installer == "" {
+		return packaging.RollbackFolder(e)
+	}
+
+	installer := fs.ExpandPath(e.Installer)
+	previous := installer + ".old"
+	swap := installer + ".rollback"
+
+	exist, err := fs.FileExist(previous)
+	if err != nil {
+		return err
+	} else if !exist {
+		return fmt.Errorf("no previous installer available: %s", installer)
+	}
+
+	// Swap installers, so rollback can be reverted
+	err = fs.MoveFile(installer, swap)
+	if err != nil {
+		return err
+	}
+
+	err = fs.MoveFile(previous, installer)
+	if err != nil {
+		return errors.Join(err, fs.MoveFile(swap, installer))
+	}
+
+	err = fs.MoveFile(swap, previous)
+	if err != nil {
+		return err
+	}
+
+	cli.Debug("Running install for %s\n", e.AppID)
+
+	context := &cli.Context{
+		WorkingDirectory: filepath.Dir(installer),
+		Executable:       installer,
+		Arguments:        e.Arguments.Install,
+		Environment:      []string{},
+	}
+
+	return context.Run()
+}
+
+// Download source ahead of install at the installer or launcher location
+func (e *Executable) Prefetch() error {
+	if e.Source == nil {
+		return nil
+	}
+	if e.Installer != "" {
+		return e.Source.Prefetch(fs.ExpandPath(e.Installer))
+	}
+
+	return e.Source.Prefetch(e.Executable())
+}
+
+// Return package download source
+func (e *Executable) GetSource() *packaging.Source {
+	return e.Source
+}
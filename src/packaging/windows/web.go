@@ -103,3 +103,8 @@ func (w *Web) Args() []string {
 	args = append(args, fmt.Sprintf("--app=%s", w.URL))
 	return args
 }
+
+// Retrieve programs required by package
+func (w *Web) Requirements() []string {
+	return []string{w.Wrapper.ID}
+}
@@ -0,0 +1,240 @@
+package configure
+
+import (
+	"path/filepath"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+)
+
+// Setting struct
+// Append adds value as a new repeated key when not already listed
+type Setting struct {
+	Section string `json:"section"`
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Append  bool   `json:"append"`
+}
+
+// Config struct
+// When requires is set, file is created once the required file exists
+type Config struct {
+	Program  string     `json:"program"`
+	Emulator string     `json:"emulator"`
+	Format   string     `json:"format"`
+	Path     string     `json:"path"`
+	Requires string     `json:"requires"`
+	Settings []*Setting `json:"settings"`
+}
+
+// Configuration for MGBA with given config file and data folder
+// Saves and states are moved from ROMs folder to the synchronized state folders
+func mgbaConfig(path string, data string) *Config {
+	return &Config{
+		Program:  "mgba",
+		Emulator: "MGBA",
+		Format:   "ini",
+		Path:     fs.ExpandPath(path),
+		Settings: []*Setting{
+			{Section: "ports.qt", Key: "savegamePath", Value: fs.ExpandPath(data + "/save")},
+			{Section: "ports.qt", Key: "savestatePath", Value: fs.ExpandPath(data + "/states")},
+			{Section: "ports.qt", Key: "gba.bios", Value: fs.ExpandPath("$BIOS/GBA/gba_bios.bin")},
+			{Section: "ports.qt", Key: "fullscreen", Value: "1"},
+		},
+	}
+}
+
+// Configuration for MelonDS with given config file and data folder
+// Saves and states are moved from ROMs folder to the synchronized state folders
+func melonDSConfig(path string, data string) *Config {
+	return &Config{
+		Program:  "melonds",
+		Emulator: "MelonDS",
+		Format:   "ini",
+		Path:     fs.ExpandPath(path),
+		Settings: []*Setting{
+			{Section: "", Key: "SaveFilePath", Value: fs.ExpandPath(data + "/save")},
+			{Section: "", Key: "SavestatePath", Value: fs.ExpandPath(data + "/states")},
+			{Section: "", Key: "BIOS9Path", Value: fs.ExpandPath("$BIOS/NDS/bios9.bin")},
+			{Section: "", Key: "BIOS7Path", Value: fs.ExpandPath("$BIOS/NDS/bios7.bin")},
+			{Section: "", Key: "FirmwarePath", Value: fs.ExpandPath("$BIOS/NDS/firmware.bin")},
+		},
+	}
+}
+
+// Configuration for PCSX2 with given config file
+func pcsx2Config(path string) *Config {
+	return &Config{
+		Program:  "pcsx2",
+		Emulator: "PCSX2",
+		Format:   "ini",
+		Path:     fs.ExpandPath(path),
+		Settings: []*Setting{
+			{Section: "Folders", Key: "Bios", Value: fs.ExpandPath("$BIOS/PS2")},
+			{Section: "UI", Key: "StartFullscreen", Value: "true"},
+			{Section: "UI", Key: "ConfirmShutdown", Value: "false"},
+			{Section: "Hotkeys", Key: "OpenPauseMenu", Value: "Keyboard/Escape"},
+			{Section: "Hotkeys", Key: "ToggleFullscreen", Value: "Keyboard/Alt & Keyboard/Return"},
+			{Section: "Hotkeys", Key: "SaveStateToSlot", Value: "Keyboard/F1"},
+			{Section: "Hotkeys", Key: "LoadStateFromSlot", Value: "Keyboard/F3"},
+		},
+	}
+}
+
+// Configuration for DuckStation with given config file
+func duckStationConfig(path string) *Config {
+	return &Config{
+		Program:  "duckstation",
+		Emulator: "DuckStation",
+		Format:   "ini",
+		Path:     fs.ExpandPath(path),
+		Settings: []*Setting{
+			{Section: "BIOS", Key: "SearchDirectory", Value: fs.ExpandPath("$BIOS/PS1")},
+			{Section: "Main", Key: "StartFullscreen", Value: "true"},
+			{Section: "Main", Key: "ConfirmPowerOff", Value: "false"},
+			{Section: "Hotkeys", Key: "OpenPauseMenu", Value: "Keyboard/Escape"},
+			{Section: "Hotkeys", Key: "ToggleFullscreen", Value: "Keyboard/F11"},
+			{Section: "Hotkeys", Key: "SaveSelectedSaveState", Value: "Keyboard/F1"},
+			{Section: "Hotkeys", Key: "LoadSelectedSaveState", Value: "Keyboard/F3"},
+		},
+	}
+}
+
+// Configuration for PPSSPP with given config file
+func ppssppConfig(path string) *Config {
+	return &Config{
+		Program:  "ppsspp",
+		Emulator: "PPSSPP",
+		Format:   "ini",
+		Path:     fs.ExpandPath(path),
+		Settings: []*Setting{
+			{Section: "General", Key: "CurrentDirectory", Value: fs.ExpandPath("$ROMS/PSP")},
+			{Section: "Graphics", Key: "FullScreen", Value: "True"},
+		},
+	}
+}
+
+// Configuration for Cemu with given config file
+func cemuConfig(path string) *Config {
+	return &Config{
+		Program:  "cemu",
+		Emulator: "Cemu",
+		Format:   "xml",
+		Path:     fs.ExpandPath(path),
+		Settings: []*Setting{
+			{Section: "content", Key: "fullscreen", Value: "true"},
+			{Section: "content", Key: "check_update", Value: "false"},
+			{Section: "content/GamePaths", Key: "Entry", Value: fs.ExpandPath("$ROMS/WIIU"), Append: true},
+		},
+	}
+}
+
+// Configuration for RetroArch with given config file
+// Cores search BIOS files on the system folder, like fbneo and dc folders
+func retroArchConfig(path string) *Config {
+	return &Config{
+		Program:  "retroarch",
+		Emulator: "RetroArch",
+		Format:   "ini",
+		Path:     fs.ExpandPath(path),
+		Settings: []*Setting{
+			{Section: "", Key: "system_directory", Value: `"` + fs.ExpandPath("$BIOS") + `"`},
+		},
+	}
+}
+
+// Configuration overrides for RetroArch cores with given config file and overrides folder
+// Cores with BIOS files use the platform folder instead of the system folder
+// Override files are loaded automatically by RetroArch and only exist when created
+func retroArchCoreConfigs(path string, folder string) []*Config {
+
+	configs := []*Config{}
+	cores := []struct {
+		Name     string
+		Platform string
+	}{
+		{Name: "Beetle Saturn", Platform: "SATURN"},
+		{Name: "melonDS DS", Platform: "NDS"},
+		{Name: "mGBA", Platform: "GBA"},
+		{Name: "SwanStation", Platform: "PS1"},
+	}
+
+	for _, core := range cores {
+		configs = append(configs, &Config{
+			Program:  "retroarch",
+			Emulator: "RetroArch (" + core.Name + ")",
+			Format:   "ini",
+			Path:     fs.ExpandPath(filepath.Join(folder, core.Name, core.Name+".cfg")),
+			Requires: fs.ExpandPath(path),
+			Settings: []*Setting{
+				{Section: "", Key: "system_directory", Value: `"` + fs.ExpandPath("$BIOS/"+core.Platform) + `"`},
+			},
+		})
+	}
+
+	return configs
+}
+
+// Retrieve recommended configuration of each emulator for the running system
+// Emulators with multiple runtimes have one configuration per runtime
+func GetConfigs() ([]*Config, error) {
+
+	configs := []*Config{}
+
+	if cli.IsLinux() {
+		configs = append(configs,
+			mgbaConfig("$VAR/io.mgba.mGBA/config/mgba/config.ini", "$VAR/io.mgba.mGBA"),
+			mgbaConfig("$CONFIG/mgba/config.ini", "$SHARE/mGBA"),
+			melonDSConfig("$VAR/net.kuribo64.melonDS/config/melonDS/melonDS.ini", "$VAR/net.kuribo64.melonDS"),
+			melonDSConfig("$CONFIG/melonDS/melonDS.ini", "$SHARE/melonDS"),
+			pcsx2Config("$VAR/net.pcsx2.PCSX2/config/PCSX2/inis/PCSX2.ini"),
+			pcsx2Config("$SHARE/PCSX2/inis/PCSX2.ini"),
+			duckStationConfig("$VAR/org.duckstation.DuckStation/config/duckstation/settings.ini"),
+			duckStationConfig("$SHARE/duckstation/settings.ini"),
+			ppssppConfig("$VAR/org.ppsspp.PPSSPP/config/ppsspp/PSP/SYSTEM/ppsspp.ini"),
+			ppssppConfig("$SHARE/ppsspp/PSP/SYSTEM/ppsspp.ini"),
+			cemuConfig("$VAR/info.cemu.Cemu/config/Cemu/settings.xml"),
+			cemuConfig("$CONFIG/Cemu/settings.xml"),
+			retroArchConfig("$VAR/org.libretro.RetroArch/config/retroarch/retroarch.cfg"),
+			retroArchConfig("$CONFIG/retroarch/retroarch.cfg"),
+		)
+		configs = append(configs, retroArchCoreConfigs(
+			"$VAR/org.libretro.RetroArch/config/retroarch/retroarch.cfg",
+			"$VAR/org.libretro.RetroArch/config/retroarch/config",
+		)...)
+		configs = append(configs, retroArchCoreConfigs(
+			"$CONFIG/retroarch/retroarch.cfg",
+			"$CONFIG/retroarch/config",
+		)...)
+	} else if cli.IsMacOS() {
+		configs = append(configs,
+			mgbaConfig("$CONFIG/mGBA/config.ini", "$CONFIG/mGBA"),
+			melonDSConfig("$CONFIG/melonDS/melonDS.ini", "$CONFIG/melonDS"),
+			pcsx2Config("$CONFIG/PCSX2/inis/PCSX2.ini"),
+			duckStationConfig("$CONFIG/DuckStation/settings.ini"),
+			ppssppConfig("$CONFIG/ppsspp/PSP/SYSTEM/ppsspp.ini"),
+			cemuConfig("$CONFIG/Cemu/settings.xml"),
+			retroArchConfig("$HOME/Library/Application Support/RetroArch/config/retroarch.cfg"),
+		)
+		configs = append(configs, retroArchCoreConfigs(
+			"$HOME/Library/Application Support/RetroArch/config/retroarch.cfg",
+			"$HOME/Library/Application Support/RetroArch/config",
+		)...)
+	} else if cli.IsWindows() {
+		configs = append(configs,
+			mgbaConfig("$EMULATORS/MGBA/config.ini", "$EMULATORS/MGBA"),
+			melonDSConfig("$EMULATORS/MelonDS/melonDS.ini", "$EMULATORS/MelonDS"),
+			pcsx2Config("$DOCUMENTS/PCSX2/inis/PCSX2.ini"),
+			duckStationConfig("$DOCUMENTS/DuckStation/settings.ini"),
+			ppssppConfig("$EMULATORS/PPSSPP/memstick/PSP/SYSTEM/ppsspp.ini"),
+			cemuConfig("$CONFIG/Cemu/settings.xml"),
+			retroArchConfig("$HOMEDRIVE/RetroArch-Win64/retroarch.cfg"),
+		)
+		configs = append(configs, retroArchCoreConfigs(
+			"$HOMEDRIVE/RetroArch-Win64/retroarch.cfg",
+			"$HOMEDRIVE/RetroArch-Win64/config",
+		)...)
+	}
+
+	return configs, nil
+}
@@ -0,0 +1,377 @@
+package configure
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+)
+
+// Document interface
+type Document interface {
+	Load() error
+	Save() error
+	Get(section string, key string) (string, bool)
+	Set(section string, key string, value string)
+	Delete(section string, key string)
+}
+
+// List interface
+// Documents with repeated keys implement it to manage list of values
+type List interface {
+	Has(section string, key string, value string) bool
+	Append(section string, key string, value string)
+	Remove(section string, key string, value string)
+}
+
+// Change struct
+// Appended changes only remove the appended value when reverting
+type Change struct {
+	Section  string `json:"section"`
+	Key      string `json:"key"`
+	Previous string `json:"previous"`
+	Existed  bool   `json:"existed"`
+	Value    string `json:"value,omitempty"`
+	Appended bool   `json:"appended,omitempty"`
+}
+
+// Record struct
+type Record struct {
+	Program string    `json:"program"`
+	Format  string    `json:"format"`
+	Path    string    `json:"path"`
+	Changes []*Change `json:"changes"`
+}
+
+// Journal struct
+// Journal keeps the original values of modified settings to allow reverting
+type Journal struct {
+	Records []*Record `json:"records"`
+}
+
+// Retrieve journal file path
+func journalPath() string {
+	return fs.ExpandPath("$APPLICATIONS/NiceDeck/configure.json")
+}
+
+// Retrieve document handler for given format and path
+func toDocument(format string, path string) (Document, error) {
+	switch format {
+	case "ini":
+		return &INI{Path: path}, nil
+	case "xml":
+		return &XML{Path: path}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported configuration format: %s", format)
+}
+
+// Find record for given path or create a new one
+func (j *Journal) record(config *Config) *Record {
+	for _, record := range j.Records {
+		if record.Path == config.Path {
+			return record
+		}
+	}
+
+	record := &Record{
+		Program: config.Program,
+		Format:  config.Format,
+		Path:    config.Path,
+		Changes: []*Change{},
+	}
+
+	j.Records = append(j.Records, record)
+	return record
+}
+
+// Retrieve configurations to apply for each program based on given options
+// Only existing configuration files are targeted, since emulators write version
+// keys on first launch and reset settings of files created without them
+// Programs without any configuration file are returned as pending
+func GetTargets(options *Options) ([]*Config, []*Config, error) {
+
+	result := []*Config{}
+	pending := []*Config{}
+
+	configs, err := GetConfigs()
+	if err != nil {
+		return result, pending, err
+	}
+
+	for _, program := range options.Programs {
+
+		candidates := []*Config{}
+		for _, config := range configs {
+			if config.Program == program {
+				candidates = append(candidates, config)
+			}
+		}
+
+		if len(candidates) == 0 {
+			continue
+		}
+
+		// Apply on every existing configuration file
+		// Files with requirement are applied when the required file exists
+		found := false
+		for _, config := range candidates {
+			if config.Requires != "" {
+				exist, err := fs.FileExist(config.Requires)
+				if err != nil {
+					return result, pending, err
+				} else if exist {
+					result = append(result, config)
+				}
+				continue
+			}
+
+			exist, err := fs.FileExist(config.Path)
+			if err != nil {
+				return result, pending, err
+			} else if exist {
+				result = append(result, config)
+				found = true
+			}
+		}
+
+		// When none exists, wait until the program creates it
+		if !found {
+			pending = append(pending, candidates[0])
+		}
+	}
+
+	return result, pending, nil
+}
+
+// Append value of setting to the list when not already listed
+// Only values appended by the process are tracked to be removed on revert
+func appendSetting(document Document, record *Record, setting *Setting) error {
+
+	list, ok := document.(List)
+	if !ok {
+		return fmt.Errorf("configuration format does not support lists: %s", record.Format)
+	}
+	if list.Has(setting.Section, setting.Key, setting.Value) {
+		return nil
+	}
+
+	tracked := slices.ContainsFunc(record.Changes, func(change *Change) bool {
+		return change.Appended && change.Section == setting.Section &&
+			change.Key == setting.Key && change.Value == setting.Value
+	})
+
+	if !tracked {
+		record.Changes = append(record.Changes, &Change{
+			Section:  setting.Section,
+			Key:      setting.Key,
+			Value:    setting.Value,
+			Appended: true,
+		})
+	}
+
+	list.Append(setting.Section, setting.Key, setting.Value)
+	return nil
+}
+
+// Apply recommended configuration for programs based on given options
+// Settings are merged into existing files and original values are kept in journal
+func Apply(options *Options) error {
+
+	journal := &Journal{}
+	err := fs.ReadJSON(journalPath(), journal)
+	if err != nil {
+		return err
+	}
+
+	targets, pending, err := GetTargets(options)
+	if err != nil {
+		return err
+	}
+
+	for _, config := range pending {
+		cli.Printf(cli.ColorWarn, "Skipping %s configuration because %s does not exist yet.\n", config.Emulator, config.Path)
+		cli.Printf(cli.ColorWarn, "Launch %s once and configure it again to apply the recommended settings.\n", config.Emulator)
+	}
+
+	if len(targets) == 0 {
+		return nil
+	}
+
+	for _, config := range targets {
+
+		cli.Printf(cli.ColorNotice, "Configuring %s at %s...\n", config.Emulator, config.Path)
+
+		document, err := toDocument(config.Format, config.Path)
+		if err != nil {
+			return err
+		}
+
+		err = document.Load()
+		if err != nil {
+			return err
+		}
+
+		// Keep only the first original value of each setting
+		// Applying configuration again will not override the original value
+		record := journal.record(config)
+		for _, setting := range config.Settings {
+			if setting.Append {
+				err := appendSetting(document, record, setting)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			tracked := slices.ContainsFunc(record.Changes, func(change *Change) bool {
+				return change.Section == setting.Section && change.Key == setting.Key
+			})
+
+			if !tracked {
+				previous, existed := document.Get(setting.Section, setting.Key)
+				record.Changes = append(record.Changes, &Change{
+					Section:  setting.Section,
+					Key:      setting.Key,
+					Previous: previous,
+					Existed:  existed,
+				})
+			}
+
+			document.Set(setting.Section, setting.Key, setting.Value)
+		}
+
+		err = document.Save()
+		if err != nil {
+			return err
+		}
+
+		// Save journal after each file to not lose original values
+		err = fs.WriteJSON(journalPath(), journal)
+		if err != nil {
+			return err
+		}
+	}
+
+	cli.Printf(cli.ColorNotice, "Configuration applied.\n")
+	return nil
+}
+
+// Retrieve programs with configuration different from the recommended values
+// Programs not configured yet or with settings changed since then have drift
+func Drift(options *Options) ([]string, error) {
+
+	result := []string{}
+
+	journal := &Journal{}
+	err := fs.ReadJSON(journalPath(), journal)
+	if err != nil {
+		return result, err
+	}
+
+	targets, _, err := GetTargets(options)
+	if err != nil {
+		return result, err
+	}
+
+	for _, config := range targets {
+		if slices.Contains(result, config.Program) {
+			continue
+		}
+
+		configured := slices.ContainsFunc(journal.Records, func(record *Record) bool {
+			return record.Path == config.Path
+		})
+		if !configured {
+			result = append(result, config.Program)
+			continue
+		}
+
+		document, err := toDocument(config.Format, config.Path)
+		if err != nil {
+			return result, err
+		}
+
+		err = document.Load()
+		if err != nil {
+			return result, err
+		}
+
+		for _, setting := range config.Settings {
+			matches := false
+			if list, ok := document.(List); ok && setting.Append {
+				matches = list.Has(setting.Section, setting.Key, setting.Value)
+			} else {
+				value, exist := document.Get(setting.Section, setting.Key)
+				matches = exist && value == setting.Value
+			}
+
+			if !matches {
+				result = append(result, config.Program)
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Revert configuration for programs to the original values based on given options
+func Revert(options *Options) error {
+
+	journal := &Journal{}
+	err := fs.ReadJSON(journalPath(), journal)
+	if err != nil {
+		return err
+	}
+
+	remaining := []*Record{}
+	for _, record := range journal.Records {
+
+		if !slices.Contains(options.Programs, record.Program) {
+			remaining = append(remaining, record)
+			continue
+		}
+
+		cli.Printf(cli.ColorNotice, "Reverting configuration at %s...\n", record.Path)
+
+		document, err := toDocument(record.Format, record.Path)
+		if err != nil {
+			return err
+		}
+
+		err = document.Load()
+		if err != nil {
+			return err
+		}
+
+		// Restore in reverse order of changes
+		for index := len(record.Changes) - 1; index >= 0; index-- {
+			change := record.Changes[index]
+			if change.Appended {
+				if list, ok := document.(List); ok {
+					list.Remove(change.Section, change.Key, change.Value)
+				}
+			} else if change.Existed {
+				document.Set(change.Section, change.Key, change.Previous)
+			} else {
+				document.Delete(change.Section, change.Key)
+			}
+		}
+
+		err = document.Save()
+		if err != nil {
+			return err
+		}
+	}
+
+	journal.Records = remaining
+	err = fs.WriteJSON(journalPath(), journal)
+	if err != nil {
+		return err
+	}
+
+	cli.Printf(cli.ColorNotice, "Configuration reverted.\n")
+	return nil
+}
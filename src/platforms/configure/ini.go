@@ -0,0 +1,174 @@
+package configure
+
+import (
+	"os"
+	"strings"
+
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+)
+
+// INI document struct
+// Document is handled line by line to preserve comments and formatting
+type INI struct {
+	Path      string
+	Lines     []string
+	Separator string
+}
+
+// Load INI document from path
+func (i *INI) Load() error {
+
+	i.Lines = []string{}
+	i.Separator = "="
+
+	exist, err := fs.FileExist(i.Path)
+	if err != nil {
+		return err
+	} else if !exist {
+		return nil
+	}
+
+	content, err := os.ReadFile(i.Path)
+	if err != nil {
+		return err
+	}
+
+	// Normalize line endings and split into lines
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+	text = strings.TrimSuffix(text, "\n")
+	if text != "" {
+		i.Lines = strings.Split(text, "\n")
+	}
+
+	// Detect separator style from the first key line
+	for _, line := range i.Lines {
+		if isKeyLine(line) {
+			if strings.Contains(line, " = ") {
+				i.Separator = " = "
+			}
+			break
+		}
+	}
+
+	return nil
+}
+
+// Save INI document on path
+func (i *INI) Save() error {
+	return fs.WriteFile(i.Path, strings.Join(i.Lines, "\n")+"\n")
+}
+
+// Check if line is a section header
+func isSectionLine(line string) bool {
+	line = strings.TrimSpace(line)
+	return strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]")
+}
+
+// Check if line is a key and value line
+func isKeyLine(line string) bool {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") {
+		return false
+	}
+
+	return !isSectionLine(line) && strings.Contains(line, "=")
+}
+
+// Split key line into key and value
+func splitKeyLine(line string) (string, string) {
+	parts := strings.SplitN(line, "=", 2)
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+}
+
+// Find line index of key and the range of given section
+// Empty section represents keys before the first section header
+func (i *INI) find(section string, key string) (int, int, int) {
+
+	keyIndex := -1
+	sectionStart := -1
+	sectionEnd := -1
+	current := ""
+
+	// Global section starts at the beginning of the file
+	if section == "" {
+		sectionStart = 0
+		sectionEnd = 0
+	}
+
+	for index, line := range i.Lines {
+		if isSectionLine(line) {
+			current = strings.Trim(strings.TrimSpace(line), "[]")
+			if current == section {
+				sectionStart = index + 1
+				sectionEnd = index + 1
+			}
+			continue
+		}
+
+		if current != section {
+			continue
+		}
+
+		// Track last content line of the section
+		if strings.TrimSpace(line) != "" {
+			sectionEnd = index + 1
+		}
+
+		if isKeyLine(line) {
+			name, _ := splitKeyLine(line)
+			if name == key {
+				keyIndex = index
+			}
+		}
+	}
+
+	return keyIndex, sectionStart, sectionEnd
+}
+
+// Retrieve value of key in section and if it exists
+func (i *INI) Get(section string, key string) (string, bool) {
+
+	index, _, _ := i.find(section, key)
+	if index == -1 {
+		return "", false
+	}
+
+	_, value := splitKeyLine(i.Lines[index])
+	return value, true
+}
+
+// Set value of key in section, creating key or section when missing
+func (i *INI) Set(section string, key string, value string) {
+
+	line := key + i.Separator + value
+	index, sectionStart, sectionEnd := i.find(section, key)
+
+	// Replace existing key
+	if index != -1 {
+		i.Lines[index] = line
+		return
+	}
+
+	// Append section at the end of file when missing
+	if sectionStart == -1 {
+		if len(i.Lines) > 0 && strings.TrimSpace(i.Lines[len(i.Lines)-1]) != "" {
+			i.Lines = append(i.Lines, "")
+		}
+		i.Lines = append(i.Lines, "["+section+"]", line)
+		return
+	}
+
+	// Insert key after the last content line of the section
+	i.Lines = append(i.Lines[:sectionEnd], append([]string{line}, i.Lines[sectionEnd:]...)...)
+}
+
+// Delete key from section
+func (i *INI) Delete(section string, key string) {
+
+	index, _, _ := i.find(section, key)
+	if index == -1 {
+		return
+	}
+
+	i.Lines = append(i.Lines[:index], i.Lines[index+1:]...)
+}
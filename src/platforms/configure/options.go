@@ -0,0 +1,20 @@
+package configure
+
+// Options struct
+type Options struct {
+	Action      string   `json:"action"`
+	Programs    []string `json:"programs"`
+	Preferences []string `json:"preferences"`
+}
+
+// Transform values into valid options
+func ToOptions(action string, programs []string, preferences []string) *Options {
+
+	options := Options{
+		Action:      action,
+		Programs:    programs,
+		Preferences: preferences,
+	}
+
+	return &options
+}
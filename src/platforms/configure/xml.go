@@ -0,0 +1,363 @@
+package configure
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+)
+
+// XML node struct
+// Offsets point to the raw content, where Start and End delimit the element
+// and Content and Close delimit the inner content between tags
+type node struct {
+	Name     string
+	Raw      string
+	Children []*node
+	Text     string
+	Start    int
+	Content  int
+	Close    int
+	End      int
+	Empty    bool
+}
+
+// XML document struct
+// Document is edited in place on the raw content to preserve comments,
+// processing instructions, namespace prefixes and formatting
+// Keys are represented as slash separated element paths, like content/fullscreen
+type XML struct {
+	Path    string
+	Content []byte
+	Root    *node
+	err     error
+}
+
+// Load XML document from path
+func (x *XML) Load() error {
+
+	x.Content = []byte{}
+	x.Root = nil
+	x.err = nil
+
+	exist, err := fs.FileExist(x.Path)
+	if err != nil {
+		return err
+	} else if !exist {
+		return nil
+	}
+
+	content, err := os.ReadFile(x.Path)
+	if err != nil {
+		return err
+	}
+
+	x.Content = content
+	return x.parse()
+}
+
+// Build node tree with offsets from raw content
+func (x *XML) parse() error {
+
+	x.Root = nil
+	decoder := xml.NewDecoder(bytes.NewReader(x.Content))
+	stack := []*node{}
+
+	for {
+		before := int(decoder.InputOffset())
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		after := int(decoder.InputOffset())
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			item := &node{
+				Name:    element.Name.Local,
+				Raw:     element.Name.Local,
+				Start:   before,
+				Content: after,
+			}
+			if element.Name.Space != "" {
+				item.Raw = element.Name.Space + ":" + element.Name.Local
+			}
+			if len(stack) == 0 {
+				x.Root = item
+			} else {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, item)
+			}
+			stack = append(stack, item)
+		case xml.EndElement:
+			if len(stack) == 0 {
+				return io.ErrUnexpectedEOF
+			}
+			item := stack[len(stack)-1]
+			item.Close = before
+			item.End = after
+			item.Empty = before == after && bytes.HasSuffix(x.Content[:after], []byte("/>"))
+			if item.Empty {
+				item.Close = after
+			}
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].Text += strings.TrimSpace(string(element))
+			}
+		}
+	}
+
+	if len(stack) > 0 {
+		return io.ErrUnexpectedEOF
+	}
+
+	return nil
+}
+
+// Replace raw content in the given range and parse the document again
+func (x *XML) replace(start int, end int, value string) {
+
+	content := make([]byte, 0, len(x.Content)+len(value))
+	content = append(content, x.Content[:start]...)
+	content = append(content, value...)
+	content = append(content, x.Content[end:]...)
+	x.Content = content
+
+	err := x.parse()
+	if err != nil && x.err == nil {
+		x.err = err
+	}
+}
+
+// Retrieve indentation of the line where node starts
+func (x *XML) indentOf(item *node) string {
+	index := item.Start
+	for index > 0 && (x.Content[index-1] == ' ' || x.Content[index-1] == '\t') {
+		index--
+	}
+	if index > 0 && x.Content[index-1] != '\n' {
+		return ""
+	}
+
+	return string(x.Content[index:item.Start])
+}
+
+// Retrieve indentation used for each level of the document
+func (x *XML) indentStep() string {
+	if x.Root != nil && len(x.Root.Children) > 0 {
+		step := strings.TrimPrefix(x.indentOf(x.Root.Children[0]), x.indentOf(x.Root))
+		if step != "" {
+			return step
+		}
+	}
+
+	return "  "
+}
+
+// Encode nested elements with names and value on the last element
+func encodeElements(names []string, value string, indent string, step string) string {
+
+	var buffer bytes.Buffer
+	buffer.WriteString("<" + names[0] + ">")
+
+	if len(names) == 1 {
+		xml.EscapeText(&buffer, []byte(value))
+	} else {
+		buffer.WriteString("\n" + indent + step)
+		buffer.WriteString(encodeElements(names[1:], value, indent+step, step))
+		buffer.WriteString("\n" + indent)
+	}
+
+	buffer.WriteString("</" + names[0] + ">")
+	return buffer.String()
+}
+
+// Insert nested elements as last children of the parent node
+func (x *XML) insert(parent *node, names []string, value string) {
+
+	step := x.indentStep()
+	indent := x.indentOf(parent)
+	childIndent := indent + step
+	if len(parent.Children) > 0 {
+		childIndent = x.indentOf(parent.Children[0])
+	}
+
+	element := encodeElements(names, value, childIndent, step)
+
+	if parent.Empty {
+		x.replace(parent.Content-2, parent.End, ">\n"+childIndent+element+"\n"+indent+"</"+parent.Raw+">")
+	} else if len(parent.Children) > 0 {
+		last := parent.Children[len(parent.Children)-1]
+		x.replace(last.End, last.End, "\n"+childIndent+element)
+	} else {
+		x.replace(parent.Content, parent.Close, "\n"+childIndent+element+"\n"+indent)
+	}
+}
+
+// Remove node from the raw content together with the indentation of its line
+func (x *XML) remove(item *node) {
+	start := item.Start
+	for start > 0 && (x.Content[start-1] == ' ' || x.Content[start-1] == '\t') {
+		start--
+	}
+	if start > 0 && x.Content[start-1] == '\n' {
+		start--
+	}
+	if start > 0 && x.Content[start-1] == '\r' {
+		start--
+	}
+
+	x.replace(start, item.End, "")
+}
+
+// Save XML document on path
+func (x *XML) Save() error {
+
+	if x.err != nil {
+		return x.err
+	}
+	if x.Root == nil {
+		return nil
+	}
+
+	return fs.WriteFile(x.Path, string(x.Content))
+}
+
+// Find child node with given name
+func findChild(parent *node, name string) *node {
+	for _, child := range parent.Children {
+		if child.Name == name {
+			return child
+		}
+	}
+
+	return nil
+}
+
+// Find node at given path with option to create missing nodes
+// First element of the path represents the root element
+func (x *XML) find(path string, create bool) (*node, *node) {
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	if x.Root == nil {
+		if !create {
+			return nil, nil
+		}
+		element := encodeElements(parts, "", "", "  ")
+		x.replace(0, len(x.Content), xml.Header+element+"\n")
+	}
+	if x.Root == nil || x.Root.Name != parts[0] {
+		return nil, nil
+	}
+
+	var parent *node
+	current := x.Root
+
+	for index, part := range parts[1:] {
+		found := findChild(current, part)
+		if found == nil {
+			if !create {
+				return nil, nil
+			}
+
+			// Create remaining elements at once and find them again
+			x.insert(current, parts[index+1:], "")
+			return x.find(path, false)
+		}
+
+		parent = current
+		current = found
+	}
+
+	return current, parent
+}
+
+// Retrieve value of key in section and if it exists
+func (x *XML) Get(section string, key string) (string, bool) {
+	item, _ := x.find(section+"/"+key, false)
+	if item == nil {
+		return "", false
+	}
+
+	return item.Text, true
+}
+
+// Set value of key in section, creating elements when missing
+func (x *XML) Set(section string, key string, value string) {
+
+	item, _ := x.find(section+"/"+key, true)
+	if item == nil {
+		return
+	}
+
+	var buffer bytes.Buffer
+	xml.EscapeText(&buffer, []byte(value))
+
+	if item.Empty {
+		x.replace(item.Content-2, item.End, ">"+buffer.String()+"</"+item.Raw+">")
+	} else {
+		x.replace(item.Content, item.Close, buffer.String())
+	}
+}
+
+// Delete key from section
+func (x *XML) Delete(section string, key string) {
+
+	item, parent := x.find(section+"/"+key, false)
+	if item == nil || parent == nil {
+		return
+	}
+
+	x.remove(item)
+}
+
+// Find repeated key in section with given value
+func (x *XML) findValue(section string, key string, value string) *node {
+
+	parent, _ := x.find(section, false)
+	if parent == nil {
+		return nil
+	}
+
+	for _, child := range parent.Children {
+		if child.Name == key && child.Text == value {
+			return child
+		}
+	}
+
+	return nil
+}
+
+// Check if section has repeated key with given value
+func (x *XML) Has(section string, key string, value string) bool {
+	return x.findValue(section, key, value) != nil
+}
+
+// Append new key with value to section, creating elements when missing
+func (x *XML) Append(section string, key string, value string) {
+
+	parent, _ := x.find(section, true)
+	if parent == nil {
+		return
+	}
+
+	x.insert(parent, []string{key}, value)
+}
+
+// Remove repeated key with given value from section
+func (x *XML) Remove(section string, key string, value string) {
+
+	item := x.findValue(section, key, value)
+	if item == nil {
+		return
+	}
+
+	x.remove(item)
+}
@@ -0,0 +1,496 @@
+package console
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+)
+
+// BIOS struct
+// File accepts glob patterns when the exact file name can vary
+// Required files in the same group are satisfied when any of them is valid
+// Folder is relative to the BIOS folder and defaults to the platform folder
+type BIOS struct {
+	Platform    string `json:"platform"`
+	Folder      string `json:"folder"`
+	File        string `json:"file"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+	Group       string `json:"group"`
+	Size        int64  `json:"size"`
+	MD5         string `json:"md5"`
+	SHA1        string `json:"sha1"`
+}
+
+// BIOS check struct
+type BIOSCheck struct {
+	File     string `json:"file"`
+	Path     string `json:"path"`
+	Status   string `json:"status"`
+	Required bool   `json:"required"`
+	Size     int64  `json:"size"`
+	MD5      string `json:"md5"`
+	SHA1     string `json:"sha1"`
+}
+
+// BIOS report struct
+type BIOSReport struct {
+	Platform string       `json:"platform"`
+	Path     string       `json:"path"`
+	Ready    bool         `json:"ready"`
+	Files    []*BIOSCheck `json:"files"`
+}
+
+// Retrieve BIOS manifest of each platform
+func GetBIOS() ([]*BIOS, error) {
+
+	list := []*BIOS{}
+
+	// Azahar can decrypt games with AES keys
+	list = append(list, &BIOS{
+		Platform:    "3DS",
+		File:        "aes_keys.txt",
+		Description: "AES keys for encrypted games",
+		Required:    false,
+	})
+
+	// Flycast and Redream have HLE BIOS, but real BIOS improves compatibility
+	// Flycast core reads them from the dc folder of RetroArch system
+	list = append(list, &BIOS{
+		Platform:    "DC",
+		Folder:      "dc",
+		File:        "dc_boot.bin",
+		Description: "Dreamcast BIOS",
+		Required:    false,
+		Size:        2097152,
+		MD5:         "e10c53c2f8b90bab96ead2d368858623",
+	}, &BIOS{
+		Platform:    "DC",
+		Folder:      "dc",
+		File:        "dc_flash.bin",
+		Description: "Dreamcast Flash",
+		Required:    false,
+		Size:        131072,
+		MD5:         "0a93f7940c455905bea6e392dfde92a4",
+	})
+
+	// MGBA has HLE BIOS, but real BIOS improves compatibility
+	list = append(list, &BIOS{
+		Platform:    "GBA",
+		File:        "gba_bios.bin",
+		Description: "Game Boy Advance BIOS",
+		Required:    false,
+		Size:        16384,
+		MD5:         "a860e8c0b6d573d191e4ec7db1b1e4f6",
+		SHA1:        "300c20df6731a33952ded8c436f7f186d25d3492",
+	})
+
+	// MelonDS has FreeBIOS, but real BIOS improves compatibility
+	list = append(list, &BIOS{
+		Platform:    "NDS",
+		File:        "bios7.bin",
+		Description: "ARM7 BIOS",
+		Required:    false,
+		Size:        16384,
+		MD5:         "df692a80a5b1bc90728bc3dfc76cd948",
+	}, &BIOS{
+		Platform:    "NDS",
+		File:        "bios9.bin",
+		Description: "ARM9 BIOS",
+		Required:    false,
+		Size:        4096,
+		MD5:         "a392174eb3e572fed6447e956bde4b25",
+	}, &BIOS{
+		Platform:    "NDS",
+		File:        "firmware.bin",
+		Description: "Firmware",
+		Required:    false,
+	})
+
+	// DuckStation requires at least one BIOS from any region
+	list = append(list, &BIOS{
+		Platform:    "PS1",
+		File:        "scph5500.bin",
+		Description: "Japan BIOS",
+		Required:    true,
+		Group:       "region",
+		Size:        524288,
+		MD5:         "8dd7d5296a650fac7319bce665a6a53c",
+	}, &BIOS{
+		Platform:    "PS1",
+		File:        "scph5501.bin",
+		Description: "North America BIOS",
+		Required:    true,
+		Group:       "region",
+		Size:        524288,
+		MD5:         "490f666e1afb15b7362b406ed1cea246",
+	}, &BIOS{
+		Platform:    "PS1",
+		File:        "scph5502.bin",
+		Description: "Europe BIOS",
+		Required:    true,
+		Group:       "region",
+		Size:        524288,
+		MD5:         "32736f17079d0b2b7024407c39bd3050",
+	}, &BIOS{
+		Platform:    "PS1",
+		File:        "scph1001.bin",
+		Description: "North America BIOS",
+		Required:    true,
+		Group:       "region",
+		Size:        524288,
+		MD5:         "924e392ed05558ffdb115408c263dccf",
+	})
+
+	// FBNeo requires the Neo Geo BIOS on its folder of RetroArch system
+	list = append(list, &BIOS{
+		Platform:    "NEOGEO",
+		Folder:      "fbneo",
+		File:        "neogeo.zip",
+		Description: "Neo Geo BIOS set",
+		Required:    true,
+	})
+
+	// PCSX2 accepts BIOS dumps from any console with any name
+	list = append(list, &BIOS{
+		Platform:    "PS2",
+		File:        "*.bin",
+		Description: "Console BIOS dump",
+		Required:    true,
+	})
+
+	// RPCS3 requires firmware to be installed from the update file
+	list = append(list, &BIOS{
+		Platform:    "PS3",
+		File:        "PS3UPDAT.PUP",
+		Description: "System firmware update",
+		Required:    true,
+	})
+
+	// Vita3K requires firmware to be installed from the update files
+	list = append(list, &BIOS{
+		Platform:    "PSVITA",
+		File:        "PSVUPDAT.PUP",
+		Description: "System firmware update",
+		Required:    true,
+	}, &BIOS{
+		Platform:    "PSVITA",
+		File:        "PSP2UPDAT.PUP",
+		Description: "System font package",
+		Required:    false,
+	})
+
+	// Beetle Saturn requires at least one BIOS from any region
+	list = append(list, &BIOS{
+		Platform:    "SATURN",
+		File:        "sega_101.bin",
+		Description: "Japan BIOS",
+		Required:    true,
+		Group:       "region",
+		Size:        524288,
+		MD5:         "85ec9ca47d8f6807718151cbcca8b964",
+	}, &BIOS{
+		Platform:    "SATURN",
+		File:        "mpr-17933.bin",
+		Description: "North America and Europe BIOS",
+		Required:    true,
+		Group:       "region",
+		Size:        524288,
+		MD5:         "3240872c70984b6cbfda1586cab68dbe",
+	})
+
+	// Switch emulators require console keys
+	list = append(list, &BIOS{
+		Platform:    "SWITCH",
+		File:        "prod.keys",
+		Description: "Console production keys",
+		Required:    true,
+	}, &BIOS{
+		Platform:    "SWITCH",
+		File:        "title.keys",
+		Description: "Console title keys",
+		Required:    false,
+	})
+
+	// Xemu requires MCPX boot ROM, flash BIOS and hard disk image
+	list = append(list, &BIOS{
+		Platform:    "XBOX",
+		File:        "mcpx_1.0.bin",
+		Description: "MCPX boot ROM",
+		Required:    true,
+		Size:        512,
+		MD5:         "d49c52a4102f6df7bcf8d0617ac475ed",
+	}, &BIOS{
+		Platform:    "XBOX",
+		File:        "Complex_4627*.bin",
+		Description: "Flash BIOS",
+		Required:    true,
+	}, &BIOS{
+		Platform:    "XBOX",
+		File:        "xbox_hdd.qcow2",
+		Description: "Hard disk image",
+		Required:    true,
+	})
+
+	// Read custom BIOS from configuration file
+	customFile := fs.ExpandPath("$APPLICATIONS/NiceDeck/custom/bios.json")
+	customBIOS := make([]*BIOS, 0)
+	err := fs.ReadJSON(customFile, &customBIOS)
+	if err != nil {
+		return list, err
+	}
+
+	// Custom entries replace built-in entries with the same file
+	for _, custom := range customBIOS {
+		list = slices.DeleteFunc(list, func(item *BIOS) bool {
+			return item.Platform == custom.Platform && item.File == custom.File
+		})
+		list = append(list, custom)
+	}
+
+	return list, nil
+}
+
+// Calculate only the hashes declared on manifest entry in a single read
+func hashBIOSFile(entry *BIOS, path string) (string, string, error) {
+
+	writers := []io.Writer{}
+	md5Hash := md5.New()
+	sha1Hash := sha1.New()
+
+	if entry.MD5 != "" {
+		writers = append(writers, md5Hash)
+	}
+	if entry.SHA1 != "" {
+		writers = append(writers, sha1Hash)
+	}
+	if len(writers) == 0 {
+		return "", "", nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", "", err
+	}
+
+	defer file.Close()
+
+	_, err = io.Copy(io.MultiWriter(writers...), file)
+	if err != nil {
+		return "", "", err
+	}
+
+	md5Sum := ""
+	sha1Sum := ""
+	if entry.MD5 != "" {
+		md5Sum = hex.EncodeToString(md5Hash.Sum(nil))
+	}
+	if entry.SHA1 != "" {
+		sha1Sum = hex.EncodeToString(sha1Hash.Sum(nil))
+	}
+
+	return md5Sum, sha1Sum, nil
+}
+
+// Check BIOS file against manifest entry and return its status
+// When verify is disabled, only presence and size of the file are checked
+func checkBIOSFile(entry *BIOS, path string, verify bool) (*BIOSCheck, error) {
+
+	check := &BIOSCheck{
+		File:     filepath.Base(path),
+		Path:     path,
+		Status:   "valid",
+		Required: entry.Required,
+	}
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		return check, err
+	}
+
+	check.Size = stat.Size()
+	if entry.Size > 0 && entry.Size != check.Size {
+		check.Status = "wrong-size"
+		return check, nil
+	} else if !verify {
+		return check, nil
+	}
+
+	// Validate hashes when available in the manifest
+	check.MD5, check.SHA1, err = hashBIOSFile(entry, path)
+	if err != nil {
+		return check, err
+	}
+
+	if entry.MD5 != "" && !strings.EqualFold(entry.MD5, check.MD5) {
+		check.Status = "wrong-hash"
+	} else if entry.SHA1 != "" && !strings.EqualFold(entry.SHA1, check.SHA1) {
+		check.Status = "wrong-hash"
+	}
+
+	return check, nil
+}
+
+// Read regular files available in the BIOS folder
+func readBIOSFolder(folder string) ([]string, error) {
+
+	files := []string{}
+	exist, err := fs.DirectoryExist(folder)
+	if err != nil {
+		return files, err
+	} else if !exist {
+		return files, nil
+	}
+
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return files, err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			files = append(files, entry.Name())
+		}
+	}
+
+	return files, nil
+}
+
+// Check BIOS files of platforms based on given options
+// Empty include list checks every platform
+// Hashes of files are only validated when verify is enabled
+func CheckBIOS(options *Options, verify bool) ([]*BIOSReport, error) {
+
+	result := []*BIOSReport{}
+
+	manifest, err := GetBIOS()
+	if err != nil {
+		return result, err
+	}
+
+	for _, platform := range options.Platforms {
+
+		// Check if should process this platform
+		if len(options.Include) > 0 && !slices.Contains(options.Include, platform.Name) {
+			continue
+		}
+
+		entries := []*BIOS{}
+		for _, entry := range manifest {
+			if entry.Platform == platform.Name {
+				entries = append(entries, entry)
+			}
+		}
+
+		report := &BIOSReport{
+			Platform: platform.Name,
+			Path:     fs.ExpandPath(filepath.Join("$BIOS", platform.Folder)),
+			Ready:    true,
+			Files:    []*BIOSCheck{},
+		}
+
+		// Read files available in the platform BIOS folder
+		// Entries with custom folder read files from their own folder
+		files, err := readBIOSFolder(report.Path)
+		if err != nil {
+			return result, err
+		}
+
+		folders := map[string][]string{report.Path: files}
+
+		// Validate each entry of the manifest
+		known := []string{}
+		satisfied := map[string]bool{}
+		pending := map[string]bool{}
+
+		for _, entry := range entries {
+
+			folder := report.Path
+			if entry.Folder != "" {
+				folder = fs.ExpandPath(filepath.Join("$BIOS", entry.Folder))
+			}
+
+			files, ok := folders[folder]
+			if !ok {
+				files, err = readBIOSFolder(folder)
+				if err != nil {
+					return result, err
+				}
+				folders[folder] = files
+			}
+
+			found := false
+			for _, file := range files {
+				matched, err := filepath.Match(strings.ToLower(entry.File), strings.ToLower(file))
+				if err != nil {
+					return result, err
+				} else if !matched {
+					continue
+				}
+
+				check, err := checkBIOSFile(entry, filepath.Join(folder, file), verify)
+				if err != nil {
+					return result, err
+				}
+
+				found = true
+				known = append(known, check.Path)
+				report.Files = append(report.Files, check)
+
+				if check.Status == "valid" && entry.Required {
+					satisfied[entry.Group+":"+entry.File] = true
+					if entry.Group != "" {
+						satisfied[entry.Group] = true
+					}
+				}
+			}
+
+			if !found {
+				report.Files = append(report.Files, &BIOSCheck{
+					File:     entry.File,
+					Path:     filepath.Join(folder, entry.File),
+					Status:   "missing",
+					Required: entry.Required,
+				})
+			}
+
+			// Track required entries to determine platform readiness
+			if entry.Required {
+				if entry.Group != "" {
+					pending[entry.Group] = true
+				} else {
+					pending[":"+entry.File] = true
+				}
+			}
+		}
+
+		for key := range pending {
+			if !satisfied[key] {
+				report.Ready = false
+			}
+		}
+
+		// Files not present in the manifest are reported as unknown
+		// Only the platform folder is checked, since other folders can be shared
+		for _, file := range folders[report.Path] {
+			if !slices.Contains(known, filepath.Join(report.Path, file)) {
+				report.Files = append(report.Files, &BIOSCheck{
+					File:   file,
+					Path:   filepath.Join(report.Path, file),
+					Status: "unknown",
+				})
+			}
+		}
+
+		result = append(result, report)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,109 @@
+package console
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+)
+
+// Media struct
+// Media tracks the removable volume where a ROMs root folder lives on
+// Hidden contains the shortcut IDs hidden while the media was absent
+type Media struct {
+	Path   string     `json:"path"`
+	Volume *fs.Volume `json:"volume"`
+	Hidden []string   `json:"hidden"`
+}
+
+// Retrieve media file path
+func mediaPath() string {
+	return fs.ExpandPath("$APPLICATIONS/NiceDeck/media.json")
+}
+
+// Retrieve tracked media from configuration file
+func GetMedia() ([]*Media, error) {
+
+	media := make([]*Media, 0)
+	err := fs.ReadJSON(mediaPath(), &media)
+	if err != nil {
+		return media, err
+	}
+
+	return media, nil
+}
+
+// Replace tracked media on configuration file
+func SetMedia(media []*Media) error {
+	return fs.WriteJSON(mediaPath(), media)
+}
+
+// Find tracked media for given ROMs root folder
+func FindMedia(media []*Media, root string) *Media {
+	for _, item := range media {
+		if item.Path == root {
+			return item
+		}
+	}
+
+	return nil
+}
+
+// Check if folder exists and has no entries
+func isEmptyFolder(path string) bool {
+	entries, err := os.ReadDir(path)
+	return err == nil && len(entries) == 0
+}
+
+// Check if ROMs root folder lives on a removable volume
+// Folders on the same volume of the home folder are not removable
+func isRemovable(volume *fs.Volume) (bool, error) {
+
+	home, err := fs.GetVolume(fs.ExpandPath("$HOME"))
+	if err != nil {
+		return false, err
+	}
+
+	return volume.ID != "" && volume.ID != home.ID, nil
+}
+
+// Check if media of the ROMs root folder is present and update tracking
+// Media is absent when the folder is missing, or when the folder is empty
+// and lives on another volume, like the mount point left after unmounting
+func CheckMedia(media []*Media, root string) ([]*Media, bool, error) {
+
+	tracked := FindMedia(media, root)
+	_, err := filepath.EvalSymlinks(root)
+	if os.IsNotExist(err) && tracked != nil {
+		return media, false, nil
+	} else if err != nil {
+		return media, false, err
+	}
+
+	volume, err := fs.GetVolume(root)
+	if err != nil {
+		return media, false, err
+	}
+
+	if tracked != nil && tracked.Volume.ID != volume.ID && isEmptyFolder(root) {
+		return media, false, nil
+	}
+
+	// Track media when ROMs live on a removable volume
+	removable, err := isRemovable(volume)
+	if err != nil {
+		return media, false, err
+	}
+
+	if removable && tracked == nil {
+		cli.Debug("Tracking media %s for ROMs at %s\n", volume.ID, root)
+		tracked = &Media{Path: root, Hidden: []string{}}
+		media = append(media, tracked)
+	}
+	if tracked != nil {
+		tracked.Volume = volume
+	}
+
+	return media, true, nil
+}
@@ -9,7 +9,9 @@ import (
 // Options struct
 type Options struct {
 	RootPath    string           `json:"rootPath"`
+	Roots       []*Root          `json:"roots"`
 	Platforms   []*Platform      `json:"platforms"`
+	Overrides   []*Override      `json:"overrides"`
 	Include     []string         `json:"include"`
 	Exclude     []*regexp.Regexp `json:"exclude"`
 	Preferences []string         `json:"preferences"`
@@ -34,7 +36,16 @@ func ToOptions(include []string, preferences []string) (*Options, error) {
 		Include:     include,
 		Preferences: preferences,
 		RootPath:    fs.ExpandPath("$ROMS"),
+		Roots:       []*Root{},
 		Platforms:   []*Platform{},
+		Overrides:   []*Override{},
+	}
+
+	roots, err := GetAllRoots()
+	if err != nil {
+		return options, err
+	} else {
+		options.Roots = roots
 	}
 
 	platforms, err := GetPlatforms()
@@ -44,6 +55,13 @@ func ToOptions(include []string, preferences []string) (*Options, error) {
 		options.Platforms = platforms
 	}
 
+	overrides, err := GetOverrides()
+	if err != nil {
+		return options, err
+	} else {
+		options.Overrides = overrides
+	}
+
 	// Files with these name patterns will be ignored
 	compile := regexp.MustCompile
 	options.Exclude = []*regexp.Regexp{
@@ -0,0 +1,100 @@
+package console
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+)
+
+// Override struct
+// Override changes how a single ROM is launched without moving the file
+// Relative path is the ROM path relative to the ROMs folder
+type Override struct {
+	RelativePath string   `json:"relativePath"`
+	Emulator     string   `json:"emulator"`
+	Arguments    string   `json:"arguments"`
+	Environment  []string `json:"environment"`
+}
+
+// Retrieve overrides file path
+func overridesPath() string {
+	return fs.ExpandPath("$APPLICATIONS/NiceDeck/overrides.json")
+}
+
+// Normalize ROM path to be used as override key
+// Accepts absolute paths inside the ROMs folders and relative paths
+func NormalizeOverridePath(path string) string {
+
+	path = filepath.Clean(filepath.FromSlash(fs.ExpandPath(path)))
+	roots, err := GetAllRoots()
+	if err != nil {
+		cli.Debug("Could not retrieve ROMs roots: %s\n", err)
+	}
+
+	root, relativePath := ToRelativePath(roots, path)
+	if root != nil {
+		path = relativePath
+	}
+
+	return path
+}
+
+// Retrieve overrides from configuration file
+func GetOverrides() ([]*Override, error) {
+
+	overrides := make([]*Override, 0)
+	err := fs.ReadJSON(overridesPath(), &overrides)
+	if err != nil {
+		return overrides, err
+	}
+
+	return overrides, nil
+}
+
+// Find override for the given ROM relative path
+func FindOverride(overrides []*Override, relativePath string) *Override {
+
+	for _, override := range overrides {
+		if strings.EqualFold(override.RelativePath, relativePath) {
+			return override
+		}
+	}
+
+	return nil
+}
+
+// Add or update override on configuration file
+func SetOverride(override *Override) error {
+
+	overrides, err := GetOverrides()
+	if err != nil {
+		return err
+	}
+
+	override.RelativePath = NormalizeOverridePath(override.RelativePath)
+	overrides = slices.DeleteFunc(overrides, func(item *Override) bool {
+		return strings.EqualFold(item.RelativePath, override.RelativePath)
+	})
+	overrides = append(overrides, override)
+
+	return fs.WriteJSON(overridesPath(), overrides)
+}
+
+// Remove override of the ROM from configuration file
+func RemoveOverride(relativePath string) error {
+
+	overrides, err := GetOverrides()
+	if err != nil {
+		return err
+	}
+
+	relativePath = NormalizeOverridePath(relativePath)
+	overrides = slices.DeleteFunc(overrides, func(item *Override) bool {
+		return strings.EqualFold(item.RelativePath, relativePath)
+	})
+
+	return fs.WriteJSON(overridesPath(), overrides)
+}
@@ -6,9 +6,11 @@ import (
 )
 
 // Emulator struct
+// Core is the libretro core name for multi-system emulators like RetroArch
 type Emulator struct {
 	Name          string `json:"name"`
 	Program       string `json:"program"`
+	Core          string `json:"core"`
 	Available     bool   `json:"available"`
 	Installed     bool   `json:"installed"`
 	Executable    string `json:"executable"`
@@ -21,6 +23,7 @@ type CustomEmulator struct {
 	Name          string `json:"name"`
 	Platform      string `json:"platform"`
 	Program       string `json:"program"`
+	Core          string `json:"core"`
 	Executable    string `json:"executable"`
 	Extensions    string `json:"extensions"`
 	LaunchOptions string `json:"launchOptions"`
@@ -42,6 +45,50 @@ type CustomPlatform struct {
 	Folder  string `json:"folder"`
 }
 
+// Retrieve custom platforms file path
+func customPlatformsPath() string {
+	return fs.ExpandPath("$APPLICATIONS/NiceDeck/custom/platforms.json")
+}
+
+// Retrieve custom emulators file path
+func customEmulatorsPath() string {
+	return fs.ExpandPath("$APPLICATIONS/NiceDeck/custom/emulators.json")
+}
+
+// Retrieve custom platforms from configuration file
+func GetCustomPlatforms() ([]CustomPlatform, error) {
+
+	customPlatforms := make([]CustomPlatform, 0)
+	err := fs.ReadJSON(customPlatformsPath(), &customPlatforms)
+	if err != nil {
+		return customPlatforms, err
+	}
+
+	return customPlatforms, nil
+}
+
+// Replace custom platforms on configuration file
+func SetCustomPlatforms(customPlatforms []CustomPlatform) error {
+	return fs.WriteJSON(customPlatformsPath(), customPlatforms)
+}
+
+// Retrieve custom emulators from configuration file
+func GetCustomEmulators() ([]CustomEmulator, error) {
+
+	customEmulators := make([]CustomEmulator, 0)
+	err := fs.ReadJSON(customEmulatorsPath(), &customEmulators)
+	if err != nil {
+		return customEmulators, err
+	}
+
+	return customEmulators, nil
+}
+
+// Replace custom emulators on configuration file
+func SetCustomEmulators(customEmulators []CustomEmulator) error {
+	return fs.WriteJSON(customEmulatorsPath(), customEmulators)
+}
+
 // Retrieve system platform specs.
 // This list is almost a copy of ES-DE systems
 func GetPlatforms() ([]*Platform, error) {
@@ -85,6 +132,15 @@ func GetPlatforms() ([]*Platform, error) {
 			Executable:    "",
 			Extensions:    ".chd .cdi .cue .gdi .7z",
 			LaunchOptions: "-b -e ${ROM}",
+		}, {
+			Name:          "RetroArch",
+			Program:       "retroarch",
+			Core:          "flycast",
+			Available:     false,
+			Installed:     false,
+			Executable:    "",
+			Extensions:    ".chd .cdi .iso .elf .cue .gdi .lst .dat .m3u .7z .zip",
+			LaunchOptions: "-f -L ${CORE} ${ROM}",
 		}},
 	})
 
@@ -101,6 +157,15 @@ func GetPlatforms() ([]*Platform, error) {
 			Executable:    "",
 			Extensions:    ".agb .bin .cgb .dmg .gb .gba .gbc .sgb .7z .zip",
 			LaunchOptions: "-f ${ROM}",
+		}, {
+			Name:          "RetroArch",
+			Program:       "retroarch",
+			Core:          "mgba",
+			Available:     false,
+			Installed:     false,
+			Executable:    "",
+			Extensions:    ".agb .bin .cgb .dmg .gb .gba .gbc .sgb .7z .zip",
+			LaunchOptions: "-f -L ${CORE} ${ROM}",
 		}},
 	})
 
@@ -117,6 +182,15 @@ func GetPlatforms() ([]*Platform, error) {
 			Executable:    "",
 			Extensions:    ".ciso .dff .dol .elf .gcm .gcz .iso .json .m3u .rvz .tgc .wad .wbfs .wia .7z .zip",
 			LaunchOptions: "-b -e ${ROM}",
+		}, {
+			Name:          "RetroArch",
+			Program:       "retroarch",
+			Core:          "dolphin",
+			Available:     false,
+			Installed:     false,
+			Executable:    "",
+			Extensions:    ".ciso .dff .dol .elf .gcm .gcz .iso .json .m3u .rvz .tgc .wad .wbfs .wia .7z .zip",
+			LaunchOptions: "-f -L ${CORE} ${ROM}",
 		}},
 	})
 
@@ -133,6 +207,15 @@ func GetPlatforms() ([]*Platform, error) {
 			Executable:    "",
 			Extensions:    ".bin .d64 .n64 .ndd .u1 .v64 .z64 .7z .zip",
 			LaunchOptions: "-f ${ROM}",
+		}, {
+			Name:          "RetroArch",
+			Program:       "retroarch",
+			Core:          "mupen64plus_next",
+			Available:     false,
+			Installed:     false,
+			Executable:    "",
+			Extensions:    ".bin .n64 .ndd .u1 .v64 .z64 .7z .zip",
+			LaunchOptions: "-f -L ${CORE} ${ROM}",
 		}},
 	})
 
@@ -149,6 +232,15 @@ func GetPlatforms() ([]*Platform, error) {
 			Executable:    "",
 			Extensions:    ".app .bin .nds .7z .zip",
 			LaunchOptions: "-f ${ROM}",
+		}, {
+			Name:          "RetroArch",
+			Program:       "retroarch",
+			Core:          "melondsds",
+			Available:     false,
+			Installed:     false,
+			Executable:    "",
+			Extensions:    ".app .bin .nds .7z .zip",
+			LaunchOptions: "-f -L ${CORE} ${ROM}",
 		}},
 	})
 
@@ -165,6 +257,15 @@ func GetPlatforms() ([]*Platform, error) {
 			Executable:    "",
 			Extensions:    ".bin .cbn .ccd .chd .cue .ecm .exe .img .iso .m3u .mdf .mds .minipsf .pbp .psexe .psf .toc .z .znx .7z .zip",
 			LaunchOptions: "-batch -fullscreen ${ROM}",
+		}, {
+			Name:          "RetroArch",
+			Program:       "retroarch",
+			Core:          "swanstation",
+			Available:     false,
+			Installed:     false,
+			Executable:    "",
+			Extensions:    ".bin .cbn .ccd .chd .cue .ecm .exe .img .iso .m3u .mdf .mds .minipsf .pbp .psexe .psf .toc .z .znx .7z .zip",
+			LaunchOptions: "-f -L ${CORE} ${ROM}",
 		}},
 	})
 
@@ -229,6 +330,15 @@ func GetPlatforms() ([]*Platform, error) {
 			Executable:    "",
 			Extensions:    ".elf .iso .cso .prx .pbp .7z .zip",
 			LaunchOptions: "-f -g ${ROM}",
+		}, {
+			Name:          "RetroArch",
+			Program:       "retroarch",
+			Core:          "ppsspp",
+			Available:     false,
+			Installed:     false,
+			Executable:    "",
+			Extensions:    ".elf .iso .cso .prx .pbp .7z .zip",
+			LaunchOptions: "-f -L ${CORE} ${ROM}",
 		}},
 	})
 
@@ -285,6 +395,15 @@ func GetPlatforms() ([]*Platform, error) {
 			Executable:    "",
 			Extensions:    ".ciso .dff .dol .elf .gcm .gcz .iso .json .m3u .rvz .tgc .wad .wbfs .wia .7z .zip",
 			LaunchOptions: "-b -e ${ROM}",
+		}, {
+			Name:          "RetroArch",
+			Program:       "retroarch",
+			Core:          "dolphin",
+			Available:     false,
+			Installed:     false,
+			Executable:    "",
+			Extensions:    ".ciso .dff .dol .elf .gcm .gcz .iso .json .m3u .rvz .tgc .wad .wbfs .wia .7z .zip",
+			LaunchOptions: "-f -L ${CORE} ${ROM}",
 		}},
 	})
 
@@ -336,6 +455,203 @@ func GetPlatforms() ([]*Platform, error) {
 		}},
 	})
 
+	// Retro platforms use RetroArch with the default core for each platform
+	platforms = append(platforms, &Platform{
+		Type:    "native",
+		Name:    "ARCADE",
+		Console: "Arcade",
+		Folder:  "ARCADE",
+		Emulators: []*Emulator{{
+			Name:          "RetroArch",
+			Program:       "retroarch",
+			Core:          "mame",
+			Available:     false,
+			Installed:     false,
+			Executable:    "",
+			Extensions:    ".7z .zip",
+			LaunchOptions: "-f -L ${CORE} ${ROM}",
+		}, {
+			Name:          "RetroArch",
+			Program:       "retroarch",
+			Core:          "fbneo",
+			Available:     false,
+			Installed:     false,
+			Executable:    "",
+			Extensions:    ".7z .zip",
+			LaunchOptions: "-f -L ${CORE} ${ROM}",
+		}},
+	})
+
+	platforms = append(platforms, &Platform{
+		Type:    "native",
+		Name:    "ATARI2600",
+		Console: "Atari 2600",
+		Folder:  "ATARI2600",
+		Emulators: []*Emulator{{
+			Name:          "RetroArch",
+			Program:       "retroarch",
+			Core:          "stella",
+			Available:     false,
+			Installed:     false,
+			Executable:    "",
+			Extensions:    ".a26 .bin .7z .zip",
+			LaunchOptions: "-f -L ${CORE} ${ROM}",
+		}},
+	})
+
+	platforms = append(platforms, &Platform{
+		Type:    "native",
+		Name:    "ATARI7800",
+		Console: "Atari 7800",
+		Folder:  "ATARI7800",
+		Emulators: []*Emulator{{
+			Name:          "RetroArch",
+			Program:       "retroarch",
+			Core:          "prosystem",
+			Available:     false,
+			Installed:     false,
+			Executable:    "",
+			Extensions:    ".a78 .bin .7z .zip",
+			LaunchOptions: "-f -L ${CORE} ${ROM}",
+		}},
+	})
+
+	platforms = append(platforms, &Platform{
+		Type:    "native",
+		Name:    "GENESIS",
+		Console: "Sega Genesis",
+		Folder:  "GENESIS",
+		Emulators: []*Emulator{{
+			Name:          "RetroArch",
+			Program:       "retroarch",
+			Core:          "genesis_plus_gx",
+			Available:     false,
+			Installed:     false,
+			Executable:    "",
+			Extensions:    ".68k .bin .bms .gen .md .sgd .smd .7z .zip",
+			LaunchOptions: "-f -L ${CORE} ${ROM}",
+		}},
+	})
+
+	platforms = append(platforms, &Platform{
+		Type:    "native",
+		Name:    "MSX",
+		Console: "MSX",
+		Folder:  "MSX",
+		Emulators: []*Emulator{{
+			Name:          "RetroArch",
+			Program:       "retroarch",
+			Core:          "bluemsx",
+			Available:     false,
+			Installed:     false,
+			Executable:    "",
+			Extensions:    ".cas .dsk .mx1 .mx2 .rom .7z .zip",
+			LaunchOptions: "-f -L ${CORE} ${ROM}",
+		}},
+	})
+
+	platforms = append(platforms, &Platform{
+		Type:    "native",
+		Name:    "NEOGEO",
+		Console: "SNK Neo Geo",
+		Folder:  "NEOGEO",
+		Emulators: []*Emulator{{
+			Name:          "RetroArch",
+			Program:       "retroarch",
+			Core:          "fbneo",
+			Available:     false,
+			Installed:     false,
+			Executable:    "",
+			Extensions:    ".7z .zip",
+			LaunchOptions: "-f -L ${CORE} ${ROM}",
+		}},
+	})
+
+	platforms = append(platforms, &Platform{
+		Type:    "native",
+		Name:    "NES",
+		Console: "Nintendo Entertainment System",
+		Folder:  "NES",
+		Emulators: []*Emulator{{
+			Name:          "RetroArch",
+			Program:       "retroarch",
+			Core:          "mesen",
+			Available:     false,
+			Installed:     false,
+			Executable:    "",
+			Extensions:    ".fds .nes .unf .unif .7z .zip",
+			LaunchOptions: "-f -L ${CORE} ${ROM}",
+		}},
+	})
+
+	platforms = append(platforms, &Platform{
+		Type:    "native",
+		Name:    "PCE",
+		Console: "NEC PC Engine",
+		Folder:  "PCE",
+		Emulators: []*Emulator{{
+			Name:          "RetroArch",
+			Program:       "retroarch",
+			Core:          "mednafen_pce_fast",
+			Available:     false,
+			Installed:     false,
+			Executable:    "",
+			Extensions:    ".ccd .chd .cue .m3u .pce .toc .7z .zip",
+			LaunchOptions: "-f -L ${CORE} ${ROM}",
+		}},
+	})
+
+	platforms = append(platforms, &Platform{
+		Type:    "native",
+		Name:    "SATURN",
+		Console: "Sega Saturn",
+		Folder:  "SATURN",
+		Emulators: []*Emulator{{
+			Name:          "RetroArch",
+			Program:       "retroarch",
+			Core:          "mednafen_saturn",
+			Available:     false,
+			Installed:     false,
+			Executable:    "",
+			Extensions:    ".ccd .chd .cue .iso .m3u .mds .toc",
+			LaunchOptions: "-f -L ${CORE} ${ROM}",
+		}},
+	})
+
+	platforms = append(platforms, &Platform{
+		Type:    "native",
+		Name:    "SMS",
+		Console: "Sega Master System",
+		Folder:  "SMS",
+		Emulators: []*Emulator{{
+			Name:          "RetroArch",
+			Program:       "retroarch",
+			Core:          "genesis_plus_gx",
+			Available:     false,
+			Installed:     false,
+			Executable:    "",
+			Extensions:    ".bin .sms .7z .zip",
+			LaunchOptions: "-f -L ${CORE} ${ROM}",
+		}},
+	})
+
+	platforms = append(platforms, &Platform{
+		Type:    "native",
+		Name:    "SNES",
+		Console: "Super Nintendo",
+		Folder:  "SNES",
+		Emulators: []*Emulator{{
+			Name:          "RetroArch",
+			Program:       "retroarch",
+			Core:          "snes9x",
+			Available:     false,
+			Installed:     false,
+			Executable:    "",
+			Extensions:    ".bin .bs .fig .mgd .sfc .smc .swc .7z .zip",
+			LaunchOptions: "-f -L ${CORE} ${ROM}",
+		}},
+	})
+
 	// Fill information on each emulator based on their program attributes
 	for _, platform := range platforms {
 		for _, emulator := range platform.Emulators {
@@ -356,9 +672,7 @@ func GetPlatforms() ([]*Platform, error) {
 	}
 
 	// Read custom platforms from configuration file
-	customPlatformsFile := fs.ExpandPath("$APPLICATIONS/NiceDeck/custom/platforms.json")
-	customPlatforms := make([]CustomPlatform, 0)
-	err := fs.ReadJSON(customPlatformsFile, &customPlatforms)
+	customPlatforms, err := GetCustomPlatforms()
 	if err != nil {
 		return platforms, err
 	}
@@ -377,9 +691,7 @@ func GetPlatforms() ([]*Platform, error) {
 	}
 
 	// Read custom emulators from configuration file
-	customEmulatorsFile := fs.ExpandPath("$APPLICATIONS/NiceDeck/custom/emulators.json")
-	customEmulators := make([]CustomEmulator, 0)
-	err = fs.ReadJSON(customEmulatorsFile, &customEmulators)
+	customEmulators, err := GetCustomEmulators()
 	if err != nil {
 		return platforms, err
 	}
@@ -390,6 +702,7 @@ func GetPlatforms() ([]*Platform, error) {
 		emulator := &Emulator{
 			Name:          customEmulator.Name,
 			Program:       customEmulator.Program,
+			Core:          customEmulator.Core,
 			Available:     true, // Custom emulators are always available
 			Installed:     true, // Custom emulators are always installed
 			Executable:    customEmulator.Executable,
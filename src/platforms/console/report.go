@@ -0,0 +1,146 @@
+package console
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+)
+
+// ROM file struct
+type ROMFile struct {
+	Path         string `json:"path"`
+	RelativePath string `json:"relativePath"`
+	Platform     string `json:"platform"`
+	Emulator     string `json:"emulator"`
+	Status       string `json:"status"`
+}
+
+// Inspect file against platforms and return its status
+// Status can be valid, unrecognized, no-emulator or extension-mismatch
+func InspectROM(relativePath string, options *Options) *ROMFile {
+
+	file := &ROMFile{
+		RelativePath: relativePath,
+		Path:         ToAbsolutePath(options.Roots, relativePath),
+		Status:       "unrecognized",
+	}
+
+	_, rootPath := SplitRelativePath(relativePath)
+	lowerPath := strings.ToLower(rootPath)
+	extension := filepath.Ext(lowerPath)
+	separator := string(os.PathSeparator)
+
+	for _, platform := range options.Platforms {
+
+		// Skip if platform folder prefix is not present in path
+		mainFolder := strings.ToLower(platform.Folder + separator)
+		if !strings.HasPrefix(lowerPath, mainFolder) {
+			continue
+		}
+
+		file.Platform = platform.Name
+		file.Status = "extension-mismatch"
+
+		// Check for emulators supporting the file extension
+		for _, emulator := range platform.Emulators {
+			valid := strings.Split(emulator.Extensions, " ")
+			if !slices.Contains(valid, extension) {
+				continue
+			}
+
+			file.Status = "no-emulator"
+			if emulator.Installed {
+				file.Emulator = emulator.Name
+				file.Status = "valid"
+				break
+			}
+		}
+
+		break
+	}
+
+	return file
+}
+
+// Inspect files in all ROMs folders and return the list with the status of each file
+// Files from platforms not included in options are ignored
+func InspectROMs(options *Options) ([]*ROMFile, error) {
+
+	var results []*ROMFile
+
+	for _, root := range options.Roots {
+		files, err := InspectRootROMs(root, options)
+		if err != nil {
+			return results, err
+		}
+
+		results = append(results, files...)
+	}
+
+	return results, nil
+}
+
+// Inspect files in root folder and return the list with the status of each file
+// Files from platforms not included in options or root are ignored
+func InspectRootROMs(root *Root, options *Options) ([]*ROMFile, error) {
+
+	var results []*ROMFile
+
+	// Get ROMs path
+	realRoot, err := filepath.EvalSymlinks(root.Path)
+	if err != nil {
+		return results, err
+	}
+
+	err = filepath.WalkDir(realRoot, func(realPath string, dir os.DirEntry, err error) error {
+
+		// Stop in case of errors
+		if err != nil {
+			return err
+		}
+
+		// Ignore hidden files and directories without a directory extension
+		if strings.HasPrefix(dir.Name(), ".") && realPath != realRoot {
+			if dir.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if dir.IsDir() && filepath.Ext(realPath) == "" {
+			return nil
+		}
+
+		// Resolve relative path and check against exclusion list
+		// Files of nested roots are inspected on their own
+		found, relativePath := ToRelativePath(options.Roots, realPath)
+		if found == nil || found.ID != root.ID {
+			return nil
+		}
+		if options.ShouldExclude(relativePath) {
+			return nil
+		}
+
+		file := InspectROM(relativePath, options)
+		if len(options.Include) > 0 && !slices.Contains(options.Include, file.Platform) {
+			return nil
+		}
+		if file.Platform != "" && !root.Allows(file.Platform) {
+			return nil
+		}
+
+		cli.Debug("Inspected: %s (%s)\n", relativePath, file.Status)
+		results = append(results, file)
+
+		// Folders with extension are ROMs, do not walk into them
+		if dir.IsDir() {
+			return filepath.SkipDir
+		}
+
+		return nil
+	})
+
+	return results, err
+}
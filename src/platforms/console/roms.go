@@ -5,15 +5,18 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/mateussouzaweb/nicedeck/src/cli"
 	"github.com/mateussouzaweb/nicedeck/src/fs"
+	"github.com/mateussouzaweb/nicedeck/src/retroarch"
 )
 
 // ROM struct
 type ROM struct {
 	Path          string `json:"path"`
 	RelativePath  string `json:"relativePath"`
+	Root          string `json:"root"`
 	Directory     string `json:"directory"`
 	File          string `json:"file"`
 	Extension     string `json:"extension"`
@@ -24,6 +27,7 @@ type ROM struct {
 	Console       string `json:"console"`
 	Emulator      string `json:"emulator"`
 	Program       string `json:"program"`
+	Core          string `json:"core"`
 	Executable    string `json:"executable"`
 	LaunchOptions string `json:"launchOptions"`
 }
@@ -33,24 +37,24 @@ func ParseROM(path string, options *Options) (*ROM, error) {
 
 	rom := &ROM{}
 
-	// Get root paths
-	root := options.RootPath
-	realRoot, err := filepath.EvalSymlinks(root)
-	if err != nil {
-		return rom, err
-	}
-
 	// Parse basic ROM information
 	directory := filepath.Dir(path)
 	file := filepath.Base(path)
 	extension := filepath.Ext(path)
 	name := strings.TrimSuffix(file, extension)
-	separator := string(os.PathSeparator)
 
 	// Ensure a valid final and relative path
 	// Final path can be represented via symbolic links
-	finalPath := strings.Replace(path, realRoot, root, 1)
-	relativePath := strings.Replace(finalPath, root+separator, "", 1)
+	// Relative path includes the root prefix for additional roots
+	finalPath := path
+	relativePath := path
+	root, rootPath := ToRelativePath(options.Roots, path)
+	if root != nil {
+		relativePath = rootPath
+		finalPath = ToAbsolutePath(options.Roots, relativePath)
+	} else {
+		root = &Root{}
+	}
 
 	// Check against exclusion list
 	if options.ShouldExclude(relativePath) {
@@ -70,6 +74,12 @@ func ParseROM(path string, options *Options) (*ROM, error) {
 		return rom, nil
 	}
 
+	// Ignore if root does not accept ROMs of the platform
+	if !root.Allows(runtime.Platform.Name) {
+		cli.Debug("Skipped: platform is not accepted on %s root\n", root.Label)
+		return rom, nil
+	}
+
 	// Valid, fill ROM data with runtime
 	executable := fs.ExpandPath(runtime.Emulator.Executable)
 	launchOptions := runtime.Emulator.LaunchOptions
@@ -77,6 +87,13 @@ func ParseROM(path string, options *Options) (*ROM, error) {
 		launchOptions, "${ROM}", cli.Quote(finalPath), 1,
 	)
 
+	// Multi-system emulators receive the core path to load
+	if runtime.Emulator.Core != "" {
+		launchOptions = strings.Replace(
+			launchOptions, "${CORE}", cli.Quote(retroarch.GetCorePath(runtime.Emulator.Core)), 1,
+		)
+	}
+
 	// Special support to inject ROM file content instead of path
 	// The file usually represents the ROM ID for the emulator
 	if strings.Contains(launchOptions, "${CONTENT}") {
@@ -90,11 +107,23 @@ func ParseROM(path string, options *Options) (*ROM, error) {
 		)
 	}
 
+	// Apply additional arguments and environment from ROM override
+	// Environment uses the same format of Steam launch options
+	override := FindOverride(options.Overrides, relativePath)
+	if override != nil && override.Arguments != "" {
+		launchOptions = strings.TrimSpace(launchOptions + " " + override.Arguments)
+	}
+	if override != nil && len(override.Environment) > 0 {
+		environment := strings.Join(override.Environment, " ")
+		launchOptions = strings.TrimSpace(environment + " %command% " + launchOptions)
+	}
+
 	title := name + " [" + runtime.Platform.Name + "]"
 	description := "ROM for " + runtime.Platform.Name
 
 	rom.Path = finalPath
 	rom.RelativePath = relativePath
+	rom.Root = root.ID
 	rom.Directory = directory
 	rom.File = file
 	rom.Extension = extension
@@ -105,66 +134,126 @@ func ParseROM(path string, options *Options) (*ROM, error) {
 	rom.Platform = runtime.Platform.Name
 	rom.Emulator = runtime.Emulator.Name
 	rom.Program = runtime.Emulator.Program
+	rom.Core = runtime.Emulator.Core
 	rom.Executable = executable
 	rom.LaunchOptions = launchOptions
 
 	return rom, nil
 }
 
-// Find ROMs in folder and return the list of detected games
+// Retrieve scan index file path
+func scanIndexPath() string {
+	return fs.ExpandPath("$APPLICATIONS/NiceDeck/scan.json")
+}
+
+// Find ROMs in all root folders and return the list of detected games
+// Scan index is used to read only folders changed since the last run
+// The rebuild preference ignores the index and scans every folder again
 func ParseROMs(options *Options) ([]*ROM, error) {
 
 	var results []*ROM
 
-	// Get ROMs path
-	root := options.RootPath
-	realRoot, err := filepath.EvalSymlinks(root)
+	index := &fs.ScanIndex{}
+	if !slices.Contains(options.Preferences, "rebuild") {
+		cached, err := fs.ReadScanIndex(scanIndexPath())
+		if err != nil {
+			cli.Debug("Could not read scan index: %s\n", err)
+		} else {
+			index = cached
+		}
+	}
+
+	for _, root := range options.Roots {
+		roms, err := parseRootROMs(root, index, options)
+		if err != nil {
+			return results, err
+		}
+
+		results = append(results, roms...)
+	}
+
+	err := fs.WriteScanIndex(scanIndexPath(), index)
 	if err != nil {
 		return results, err
 	}
 
-	cli.Printf(cli.ColorNotice, "Checking for ROMs available at: %s\n", root)
+	return results, nil
+}
 
-	// Note: walkDir does not follow symbolic links
-	err = filepath.WalkDir(realRoot, func(realPath string, dir os.DirEntry, err error) error {
+// Find ROMs in root folder and return the list of detected games
+func parseRootROMs(root *Root, index *fs.ScanIndex, options *Options) ([]*ROM, error) {
 
-		// Stop in case of errors
-		if err != nil {
-			return err
-		}
+	var results []*ROM
+
+	// Get ROMs path
+	realRoot, err := filepath.EvalSymlinks(root.Path)
+	if err != nil {
+		return results, err
+	}
 
-		// Ignore directories without a directory extension
-		// Please note that some emulators like PS3/PS4 use folders as ROM
-		// These folders will always have a directory extension
-		// So we only skip folders without an extension
-		if dir.IsDir() && filepath.Ext(realPath) == "" {
+	cli.Printf(cli.ColorNotice, "Checking for ROMs available at: %s\n", root.Path)
+
+	// Scan phase: find candidate paths in the folder tree
+	// Please note that some emulators like PS3/PS4 use folders as ROM
+	// These folders will always have a directory extension
+	// So we only skip folders without an extension
+	start := time.Now()
+	cached, scanned := index.Cached, index.Scanned
+	candidates := []string{}
+	err = index.Walk(realRoot, func(realPath string, entry *fs.ScanEntry) error {
+		if entry.Directory && filepath.Ext(realPath) == "" {
 			return nil
 		}
 
-		// Parse individual ROM file
+		candidates = append(candidates, realPath)
+		return nil
+	})
+	if err != nil {
+		return results, err
+	}
+
+	cli.Debug(
+		"Scanned %s in %s: %d folders cached, %d folders scanned\n",
+		root.Path, time.Since(start), index.Cached-cached, index.Scanned-scanned,
+	)
+
+	// Parse phase: parse individual ROM files
+	// Same ROM found with another extension is detected in the lookup table
+	// This will prevent multiple results for the same ROM
+	start = time.Now()
+	type key struct{ platform, name string }
+	found := make(map[key]bool)
+	for _, realPath := range candidates {
+
 		cli.Debug("Detected: %s\n", realPath)
 		rom, err := ParseROM(realPath, options)
 		if err != nil {
-			return err
+			return results, err
 		} else if rom.Name == "" {
-			return nil
+			continue
 		}
 
-		// Check if same ROM already was found with another extension
-		// This will prevent multiple results for the same ROM
-		for _, item := range results {
-			if item.Platform == rom.Platform && item.Name == rom.Name {
-				cli.Debug("Skipped: multiple results detected for %s\n", rom.Name)
-				return nil
-			}
+		// Nested roots are parsed on their own
+		if rom.Root != root.ID {
+			continue
+		}
+
+		if found[key{rom.Platform, rom.Name}] {
+			cli.Debug("Skipped: multiple results detected for %s\n", rom.Name)
+			continue
 		}
 
 		cli.Debug("Valid: ROM is valid for %s emulator\n", rom.Emulator)
+		found[key{rom.Platform, rom.Name}] = true
 		results = append(results, rom)
-		return nil
-	})
+	}
+
+	cli.Debug(
+		"Parsed %d candidates of %s in %s: %d ROMs found\n",
+		len(candidates), root.Path, time.Since(start), len(results),
+	)
 
-	return results, err
+	return results, nil
 }
 
 // Filter ROMs that match given requirements and return the list to process
@@ -0,0 +1,162 @@
+package console
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+)
+
+// Root struct
+// Root is a folder with ROMs, the main root is the ROMs folder itself
+// Additional roots have an unique ID to identify their ROMs
+// When platforms are declared, only ROMs of these platforms are detected
+type Root struct {
+	ID        string   `json:"id"`
+	Label     string   `json:"label"`
+	Path      string   `json:"path"`
+	Platforms []string `json:"platforms"`
+}
+
+// Prefix used in relative path of ROMs from the root
+// Main root has no prefix to keep relative paths of existing ROMs
+func (r *Root) Prefix() string {
+	if r.ID == "" {
+		return ""
+	}
+	return "@" + r.ID + string(os.PathSeparator)
+}
+
+// Check if root accepts ROMs of the given platform
+func (r *Root) Allows(platform string) bool {
+	return len(r.Platforms) == 0 || slices.Contains(r.Platforms, platform)
+}
+
+// Retrieve roots file path
+func rootsPath() string {
+	return fs.ExpandPath("$APPLICATIONS/NiceDeck/roots.json")
+}
+
+// Retrieve additional roots from configuration file
+func GetRoots() ([]Root, error) {
+
+	roots := make([]Root, 0)
+	err := fs.ReadJSON(rootsPath(), &roots)
+	if err != nil {
+		return roots, err
+	}
+
+	return roots, nil
+}
+
+// Replace additional roots on configuration file
+func SetRoots(roots []Root) error {
+	return fs.WriteJSON(rootsPath(), roots)
+}
+
+// Retrieve main root and additional roots with validated values
+func GetAllRoots() ([]*Root, error) {
+
+	results := []*Root{{
+		ID:    "",
+		Label: "ROMs",
+		Path:  fs.ExpandPath("$ROMS"),
+	}}
+
+	roots, err := GetRoots()
+	if err != nil {
+		return results, err
+	}
+
+	valid := regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+	for _, root := range roots {
+		if !valid.MatchString(root.ID) {
+			return results, fmt.Errorf("invalid ROMs root ID: %s", root.ID)
+		}
+		if slices.ContainsFunc(results, func(item *Root) bool { return item.ID == root.ID }) {
+			return results, fmt.Errorf("duplicated ROMs root ID: %s", root.ID)
+		}
+
+		root.Path = filepath.Clean(fs.ExpandPath(root.Path))
+		if root.Label == "" {
+			root.Label = root.ID
+		}
+
+		results = append(results, &root)
+	}
+
+	return results, nil
+}
+
+// Split relative path into the root ID and path inside the root
+func SplitRelativePath(relativePath string) (string, string) {
+
+	if !strings.HasPrefix(relativePath, "@") {
+		return "", relativePath
+	}
+
+	ID, path, found := strings.Cut(relativePath[1:], string(os.PathSeparator))
+	if !found {
+		return "", relativePath
+	}
+
+	return ID, path
+}
+
+// Find root with given ID in the list of roots
+func FindRoot(roots []*Root, ID string) *Root {
+	for _, root := range roots {
+		if root.ID == ID {
+			return root
+		}
+	}
+
+	return nil
+}
+
+// Convert absolute path into relative path of the deepest root containing it
+// Returns empty values when path is not inside any root
+func ToRelativePath(roots []*Root, path string) (*Root, string) {
+
+	var found *Root
+	relativePath := ""
+	separator := string(os.PathSeparator)
+
+	for _, root := range roots {
+		rootPaths := []string{filepath.Clean(root.Path)}
+		realRoot, err := filepath.EvalSymlinks(root.Path)
+		if err == nil {
+			rootPaths = append(rootPaths, realRoot)
+		}
+
+		for _, rootPath := range rootPaths {
+			if !strings.HasPrefix(path, rootPath+separator) {
+				continue
+			}
+			if found != nil && len(found.Path) >= len(root.Path) {
+				continue
+			}
+
+			found = root
+			relativePath = root.Prefix() + strings.TrimPrefix(path, rootPath+separator)
+		}
+	}
+
+	return found, relativePath
+}
+
+// Convert relative path of ROM into absolute path on its root
+func ToAbsolutePath(roots []*Root, relativePath string) string {
+
+	ID, path := SplitRelativePath(relativePath)
+	root := FindRoot(roots, ID)
+	if root == nil {
+		return relativePath
+	}
+
+	return filepath.Join(root.Path, path)
+}
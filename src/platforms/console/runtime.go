@@ -13,6 +13,18 @@ type Runtime struct {
 	Emulator *Emulator
 }
 
+// Check if emulator matches the given name
+// Emulators sharing the same name, like RetroArch with multiple cores
+// on the same platform, also match the name with core, like RetroArch-FBNeo
+func (e *Emulator) Matches(name string) bool {
+	name = strings.ReplaceAll(name, " ", "-")
+	if strings.EqualFold(strings.ReplaceAll(e.Name, " ", "-"), name) {
+		return true
+	}
+
+	return e.Core != "" && strings.EqualFold(strings.ReplaceAll(e.Name, " ", "-")+"-"+e.Core, name)
+}
+
 // Find runtime specs for ROM based on their path
 func FindRuntime(romPath string, options *Options) (*Runtime, error) {
 
@@ -21,6 +33,9 @@ func FindRuntime(romPath string, options *Options) (*Runtime, error) {
 		Emulator: &Emulator{},
 	}
 
+	// Platform is detected from the path inside the root
+	override := FindOverride(options.Overrides, romPath)
+	_, romPath = SplitRelativePath(romPath)
 	romPath = strings.ToLower(romPath)
 	romExtension := filepath.Ext(romPath)
 
@@ -59,15 +74,23 @@ func FindRuntime(romPath string, options *Options) (*Runtime, error) {
 			continue
 		}
 
+		// Override of the ROM has priority to enforce an specific emulator
+		if override != nil && override.Emulator != "" {
+			for _, emulator := range emulators {
+				if emulator.Matches(override.Emulator) {
+					result.Platform = platform
+					result.Emulator = emulator
+					return result, nil
+				}
+			}
+		}
+
 		// Special case to enforce an specific emulator of the platform
 		// The condition is to have the emulator name as subfolder
 		// Please note that is important to check subfolder with path separator
+		subFolder, _, found := strings.Cut(strings.TrimPrefix(romPath, mainFolder), separator)
 		for _, emulator := range emulators {
-			subFolder := strings.ReplaceAll(emulator.Name, " ", "-")
-			subFolder = filepath.Join(mainFolder, subFolder)
-			subFolder = strings.ToLower(subFolder + separator)
-
-			if !strings.HasPrefix(romPath, subFolder) {
+			if !found || !emulator.Matches(subFolder) {
 				continue
 			} else {
 				result.Platform = platform
@@ -0,0 +1,15 @@
+package platforms
+
+import (
+	"github.com/mateussouzaweb/nicedeck/src/platforms/console"
+	"github.com/mateussouzaweb/nicedeck/src/shortcuts"
+)
+
+// Report struct
+// Orphans are ROM shortcuts without the matching file in the ROMs folder
+// Files contains only files with issues that are not processed as ROMs
+type Report struct {
+	Total   int                   `json:"total"`
+	Orphans []*shortcuts.Shortcut `json:"orphans"`
+	Files   []*console.ROMFile    `json:"files"`
+}
@@ -0,0 +1,283 @@
+package state
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+)
+
+// Change struct
+type Change struct {
+	Path            string `json:"path"`
+	Action          string `json:"action"`
+	Issue           string `json:"issue"`
+	SourceSize      int64  `json:"sourceSize"`
+	SourceHash      string `json:"sourceHash"`
+	DestinationSize int64  `json:"destinationSize"`
+	DestinationHash string `json:"destinationHash"`
+}
+
+// Diff struct
+type Diff struct {
+	Platform    string    `json:"platform"`
+	Type        string    `json:"type"`
+	Source      string    `json:"source"`
+	Destination string    `json:"destination"`
+	Changes     []*Change `json:"changes"`
+	Issues      int       `json:"issues"`
+}
+
+// File entry struct
+type entry struct {
+	Path string
+	Size int64
+}
+
+// List files inside given path indexed by relative path
+// When path is a file, result contains only the file itself
+// Folder files not allowed by include and exclude patterns are ignored
+func listEntries(path string, isFile bool, include []string, exclude []string) (map[string]*entry, error) {
+
+	result := map[string]*entry{}
+
+	// Ignore paths that does not exist
+	exist := false
+	var err error
+	if isFile {
+		exist, err = fs.FileExist(path)
+	} else {
+		exist, err = fs.DirectoryExist(path)
+	}
+	if err != nil {
+		return result, err
+	} else if !exist {
+		return result, nil
+	}
+
+	// Single file entry uses an empty relative path
+	// This allows comparing files with different names
+	if isFile {
+		stat, err := os.Stat(path)
+		if err != nil {
+			return result, err
+		}
+
+		result[""] = &entry{
+			Path: path,
+			Size: stat.Size(),
+		}
+
+		return result, nil
+	}
+
+	// Note: walkDir does not follow symbolic links
+	err = filepath.WalkDir(path, func(filePath string, dir os.DirEntry, err error) error {
+
+		// Stop in case of errors
+		if err != nil {
+			return err
+		}
+
+		// Ignore directories and non regular files
+		if !dir.Type().IsRegular() {
+			return nil
+		}
+
+		// Get file info
+		fileInfo, err := dir.Info()
+		if err != nil {
+			return err
+		}
+
+		relativePath, err := filepath.Rel(path, filePath)
+		if err != nil {
+			return err
+		}
+
+		// Ignore files not allowed by rules
+		if !Allowed(relativePath, include, exclude) {
+			return nil
+		}
+
+		result[relativePath] = &entry{
+			Path: filePath,
+			Size: fileInfo.Size(),
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// Check if content of source file is the start of destination file
+// Used to detect truncated copies of a previous file
+func isPrefix(source string, destination string) (bool, error) {
+
+	sourceContent, err := os.ReadFile(source)
+	if err != nil {
+		return false, err
+	}
+
+	destinationFile, err := os.Open(destination)
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		errors.Join(err, destinationFile.Close())
+	}()
+
+	destinationContent := make([]byte, len(sourceContent))
+	_, err = io.ReadFull(destinationFile, destinationContent)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(sourceContent, destinationContent), nil
+}
+
+// Compare source and destination of synchronizable item and return the diff
+// Diff represents what would change on destination after synchronization
+func CompareSynchronizable(item *Synchronizable) (*Diff, error) {
+
+	diff := &Diff{
+		Platform:    item.Platform,
+		Type:        item.Type,
+		Source:      item.Source.Path,
+		Destination: item.Destination.Path,
+		Changes:     []*Change{},
+		Issues:      0,
+	}
+
+	// Display path for changes, where single files use the destination name
+	displayPath := func(relativePath string) string {
+		if relativePath == "" {
+			return filepath.Base(item.Destination.Path)
+		}
+		return relativePath
+	}
+
+	// Read entries from both sides
+	isFile := item.Type == "file"
+	sourceEntries, err := listEntries(item.Source.Path, isFile, item.Include, item.Exclude)
+	if err != nil {
+		return diff, err
+	}
+
+	destinationEntries, err := listEntries(item.Destination.Path, isFile, item.Include, item.Exclude)
+	if err != nil {
+		return diff, err
+	}
+
+	// Detect added and modified files
+	for relativePath, source := range sourceEntries {
+
+		sourceHash, err := fs.Checksum(source.Path)
+		if err != nil {
+			return diff, err
+		}
+
+		change := &Change{
+			Path:       displayPath(relativePath),
+			Action:     "added",
+			SourceSize: source.Size,
+			SourceHash: sourceHash,
+		}
+
+		destination, exist := destinationEntries[relativePath]
+		if exist {
+			destinationHash, err := fs.Checksum(destination.Path)
+			if err != nil {
+				return diff, err
+			}
+
+			// Unchanged files are not part of the diff
+			if sourceHash == destinationHash {
+				continue
+			}
+
+			change.Action = "modified"
+			change.DestinationSize = destination.Size
+			change.DestinationHash = destinationHash
+		}
+
+		// Check for integrity issues on the content to be copied
+		if source.Size == 0 {
+			change.Issue = "zero-byte"
+		} else if exist && source.Size < destination.Size {
+			truncated, err := isPrefix(source.Path, destination.Path)
+			if err != nil {
+				return diff, err
+			}
+			if truncated {
+				change.Issue = "truncated"
+			}
+		}
+
+		if change.Issue != "" {
+			diff.Issues++
+		}
+
+		diff.Changes = append(diff.Changes, change)
+	}
+
+	// Detect files that exist only on destination
+	// Synchronization never removes files, so these are informative
+	for relativePath, destination := range destinationEntries {
+		if _, exist := sourceEntries[relativePath]; exist {
+			continue
+		}
+
+		destinationHash, err := fs.Checksum(destination.Path)
+		if err != nil {
+			return diff, err
+		}
+
+		diff.Changes = append(diff.Changes, &Change{
+			Path:            displayPath(relativePath),
+			Action:          "deleted",
+			DestinationSize: destination.Size,
+			DestinationHash: destinationHash,
+		})
+	}
+
+	// Sort changes for consistent output
+	sort.Slice(diff.Changes, func(i int, j int) bool {
+		return diff.Changes[i].Path < diff.Changes[j].Path
+	})
+
+	return diff, nil
+}
+
+// Retrieve diff of each synchronizable item based on given options
+func GetDiff(options *Options) ([]*Diff, error) {
+
+	result := []*Diff{}
+
+	// Get synchronizable information based on state and options
+	synchronizable, err := GetSynchronizables(options)
+	if err != nil {
+		return result, err
+	}
+
+	// Compare each synchronizable item
+	for _, item := range synchronizable {
+		cli.Debug("Comparing %s with %s\n", item.Source.Path, item.Destination.Path)
+
+		diff, err := CompareSynchronizable(item)
+		if err != nil {
+			return result, err
+		}
+
+		result = append(result, diff)
+	}
+
+	return result, nil
+}
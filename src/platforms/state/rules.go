@@ -0,0 +1,142 @@
+package state
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+)
+
+// Exclusion patterns applied to built-in folder states
+// Emulators usually mix these folders with saves, resulting in huge backups
+var defaultExclude = []string{
+	"shaders/",
+	"shadercache/",
+	"cache/",
+	"logs/",
+	"*.log",
+}
+
+// Check if pattern segments matches the path segments
+// The special segment ** matches zero or more path segments
+func matchSegments(pattern []string, segments []string) bool {
+
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for index := 0; index <= len(segments); index++ {
+			if matchSegments(pattern[1:], segments[index:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+
+	matched, err := path.Match(pattern[0], segments[0])
+	if err != nil || !matched {
+		return false
+	}
+
+	return matchSegments(pattern[1:], segments[1:])
+}
+
+// Check if relative path matches the given glob pattern
+// Patterns follow a simplified gitignore syntax:
+// - Patterns without slash match the name at any depth, like *.log
+// - Patterns ending with slash match the folder and all its content, like cache/
+// - Patterns with slash are anchored to the root, like data/*.sav
+// - The ** segment matches any number of folders, like **/shaders/**
+func MatchPattern(pattern string, relativePath string) bool {
+
+	pattern = strings.TrimPrefix(filepath.ToSlash(pattern), "/")
+	relativePath = filepath.ToSlash(relativePath)
+
+	// Detect folder pattern before normalization
+	isFolder := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	// Non anchored patterns can match at any depth
+	if !strings.Contains(pattern, "/") {
+		pattern = "**/" + pattern
+	}
+
+	// Folder patterns match any children, but not the folder name as file
+	if isFolder {
+		pattern = pattern + "/**/*"
+	}
+
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(relativePath, "/"))
+}
+
+// Check if relative path is allowed by include and exclude patterns
+// Empty include list means that all paths are included
+func Allowed(relativePath string, include []string, exclude []string) bool {
+
+	if len(include) > 0 {
+		found := false
+		for _, pattern := range include {
+			if MatchPattern(pattern, relativePath) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for _, pattern := range exclude {
+		if MatchPattern(pattern, relativePath) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Copy folder content to destination respecting include and exclude patterns
+// When content already exists, it will be replaced
+func copyFolder(source string, destination string, include []string, exclude []string) error {
+
+	// Without rules, simply copy the whole directory
+	if len(include) == 0 && len(exclude) == 0 {
+		return fs.CopyDirectory(source, destination)
+	}
+
+	// Note: walkDir does not follow symbolic links
+	return filepath.WalkDir(source, func(filePath string, dir os.DirEntry, err error) error {
+
+		// Stop in case of errors
+		if err != nil {
+			return err
+		}
+
+		// Ignore directories and non regular files
+		if !dir.Type().IsRegular() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(source, filePath)
+		if err != nil {
+			return err
+		}
+
+		// Skip files not allowed by rules
+		if !Allowed(relativePath, include, exclude) {
+			cli.Debug("Skipping excluded file: %s\n", filePath)
+			return nil
+		}
+
+		destinationPath := filepath.Join(destination, relativePath)
+		return fs.CopyFile(filePath, destinationPath, true)
+	})
+}
@@ -4,20 +4,47 @@ import "github.com/mateussouzaweb/nicedeck/src/fs"
 
 // State struct
 type State struct {
-	Platform    string  `json:"platform"`
-	Emulator    string  `json:"emulator"`
-	Type        string  `json:"type"`
-	Destination string  `json:"destination"`
-	Source      *Source `json:"source"`
+	Platform    string   `json:"platform"`
+	Emulator    string   `json:"emulator"`
+	Type        string   `json:"type"`
+	Destination string   `json:"destination"`
+	Source      *Source  `json:"source"`
+	Include     []string `json:"include"`
+	Exclude     []string `json:"exclude"`
 }
 
 // Custom state struct
 type CustomState struct {
-	Platform    string `json:"platform"`
-	Emulator    string `json:"emulator"`
-	Type        string `json:"type"`
-	Source      string `json:"source"`
-	Destination string `json:"destination"`
+	Platform    string   `json:"platform"`
+	Emulator    string   `json:"emulator"`
+	Type        string   `json:"type"`
+	Source      string   `json:"source"`
+	Sources     *Source  `json:"sources"`
+	Destination string   `json:"destination"`
+	Include     []string `json:"include"`
+	Exclude     []string `json:"exclude"`
+}
+
+// Retrieve custom states file path
+func customStatesPath() string {
+	return fs.ExpandPath("$APPLICATIONS/NiceDeck/custom/states.json")
+}
+
+// Retrieve custom states from configuration file
+func GetCustomStates() ([]CustomState, error) {
+
+	customStates := make([]CustomState, 0)
+	err := fs.ReadJSON(customStatesPath(), &customStates)
+	if err != nil {
+		return customStates, err
+	}
+
+	return customStates, nil
+}
+
+// Replace custom states on configuration file
+func SetCustomStates(customStates []CustomState) error {
+	return fs.WriteJSON(customStatesPath(), customStates)
 }
 
 // Retrieve save state of each platform
@@ -26,6 +53,7 @@ func GetStates(options *Options) ([]*State, error) {
 	// The following emulators store saves and states on ROMs directory:
 	// - MGBA (user can leave at it is or configure emulator)
 	// - MelonDS (user can leave at it is or configure emulator)
+	// Emulators are configured automatically after install with the configure command
 	states := []*State{}
 
 	// Azahar
@@ -83,7 +111,7 @@ func GetStates(options *Options) ([]*State, error) {
 		},
 	})
 
-	// MGBA requires configuration to work:
+	// MGBA requires configuration to work (done by the configure command):
 	// - Go to Tools > Settings > Paths
 	// - Set save games location as $VAR/io.mgba.mGBA/save
 	// - Set save states as $VAR/io.mgba.mGBA/states
@@ -147,7 +175,7 @@ func GetStates(options *Options) ([]*State, error) {
 		},
 	})
 
-	// MelonDS requires configuration to work:
+	// MelonDS requires configuration to work (done by the configure command):
 	// - Go to Config > Path Settings
 	// - Set save files path as $VAR/net.kuribo64.melonDS/save
 	// - Set save states path as $VAR/net.kuribo64.melonDS/states
@@ -490,26 +518,65 @@ func GetStates(options *Options) ([]*State, error) {
 		},
 	})
 
+	// RetroArch stores saves and states of every core in shared folders
+	// Each retro platform points to the same folders to allow platform selection
+	retroPlatforms := []string{
+		"ARCADE", "ATARI2600", "ATARI7800", "GENESIS", "MSX", "NEOGEO",
+		"NES", "PCE", "SATURN", "SMS", "SNES",
+	}
+	for _, platform := range retroPlatforms {
+		for _, folder := range []string{"saves", "states"} {
+			states = append(states, &State{
+				Platform:    platform,
+				Emulator:    "RetroArch",
+				Type:        "folder",
+				Destination: "$STATE/RetroArch/" + folder,
+				Source: &Source{
+					Linux: []string{
+						"$VAR/org.libretro.RetroArch/config/retroarch/" + folder,
+						"$CONFIG/retroarch/" + folder,
+					},
+					MacOS:   []string{"$HOME/Library/Application Support/RetroArch/" + folder},
+					Windows: []string{"$HOMEDRIVE/RetroArch-Win64/" + folder},
+				},
+			})
+		}
+	}
+
+	// Built-in folder states skip caches and logs by default
+	for _, state := range states {
+		if state.Type == "folder" && len(state.Exclude) == 0 {
+			state.Exclude = defaultExclude
+		}
+	}
+
 	// Read custom states from configuration file
-	customFile := fs.ExpandPath("$APPLICATIONS/NiceDeck/custom/states.json")
-	customStates := make([]CustomState, 0)
-	err := fs.ReadJSON(customFile, &customStates)
+	customStates, err := GetCustomStates()
 	if err != nil {
 		return states, err
 	}
 
 	// Merge custom states with the built-in states
 	for _, customState := range customStates {
+
+		// Per system sources have priority over the single source
+		source := customState.Sources
+		if source == nil {
+			source = &Source{
+				Linux:   []string{customState.Source},
+				MacOS:   []string{customState.Source},
+				Windows: []string{customState.Source},
+			}
+		}
+
 		state := &State{
 			Platform:    customState.Platform,
 			Emulator:    customState.Emulator,
 			Type:        customState.Type,
 			Destination: customState.Destination,
-			Source: &Source{
-				Linux:   []string{customState.Source},
-				MacOS:   []string{customState.Source},
-				Windows: []string{customState.Source},
-			},
+			Source:      source,
+			Include:     customState.Include,
+			Exclude:     customState.Exclude,
 		}
 
 		states = append(states, state)
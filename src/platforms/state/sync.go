@@ -13,6 +13,8 @@ type Synchronizable struct {
 	Type        string   `json:"type"`
 	Source      *fs.Info `json:"source"`
 	Destination *fs.Info `json:"destination"`
+	Include     []string `json:"include"`
+	Exclude     []string `json:"exclude"`
 	Recommended bool     `json:"recommended"`
 }
 
@@ -27,6 +29,7 @@ func GetSynchronizables(options *Options) ([]*Synchronizable, error) {
 	}
 
 	// Process each state
+	processed := []string{}
 	for _, state := range states {
 
 		// Check if should process this platform
@@ -34,6 +37,13 @@ func GetSynchronizables(options *Options) ([]*Synchronizable, error) {
 			continue
 		}
 
+		// Multi-system emulators share the same state across platforms
+		// Process each destination only once to avoid duplicated work
+		if slices.Contains(processed, state.Destination) {
+			continue
+		}
+		processed = append(processed, state.Destination)
+
 		// Backup action copy from platform source to state destination
 		if options.Action == "backup" {
 			destination, err := fs.GetInfo(state.Destination)
@@ -91,6 +101,8 @@ func GetSynchronizables(options *Options) ([]*Synchronizable, error) {
 					Type:        state.Type,
 					Source:      source,
 					Destination: destination,
+					Include:     state.Include,
+					Exclude:     state.Exclude,
 					Recommended: recommended,
 				})
 
@@ -159,6 +171,8 @@ func GetSynchronizables(options *Options) ([]*Synchronizable, error) {
 					Type:        state.Type,
 					Source:      source,
 					Destination: destination,
+					Include:     state.Include,
+					Exclude:     state.Exclude,
 					Recommended: recommended,
 				})
 
@@ -209,7 +223,7 @@ func SyncState(options *Options) error {
 
 			// Recursive copy content
 			cli.Printf(cli.ColorNotice, "Synchronizing folder from %s to %s...\n", source, destination)
-			err = fs.CopyDirectory(source, destination)
+			err = copyFolder(source, destination, item.Include, item.Exclude)
 			if err != nil {
 				return err
 			}
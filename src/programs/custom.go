@@ -0,0 +1,281 @@
+package programs
+
+import (
+	"fmt"
+
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+	"github.com/mateussouzaweb/nicedeck/src/packaging"
+	"github.com/mateussouzaweb/nicedeck/src/packaging/linux"
+	"github.com/mateussouzaweb/nicedeck/src/packaging/macos"
+	"github.com/mateussouzaweb/nicedeck/src/packaging/windows"
+	"github.com/mateussouzaweb/nicedeck/src/programs/forgejo"
+	"github.com/mateussouzaweb/nicedeck/src/programs/github"
+	"github.com/mateussouzaweb/nicedeck/src/programs/gitlab"
+	"github.com/mateussouzaweb/nicedeck/src/programs/website"
+)
+
+// Custom source struct
+// Type determines how the download URL is resolved:
+// - github: latest release of repository matching search pattern
+// - gitlab: latest release of project on domain matching search pattern
+// - forgejo: latest release of repository on domain matching search pattern
+// - website: link on page URL with prefix matching search pattern
+// - link: direct download URL
+// Checksum files of releases are detected automatically, but expected
+// checksum, checksum URL and signature can also be declared
+type CustomSource struct {
+	Type        string               `json:"type"`
+	Domain      string               `json:"domain"`
+	Repository  string               `json:"repository"`
+	Search      string               `json:"search"`
+	URL         string               `json:"url"`
+	Prefix      string               `json:"prefix"`
+	Checksum    string               `json:"checksum"`
+	ChecksumURL string               `json:"checksumUrl"`
+	Signature   *packaging.Signature `json:"signature"`
+}
+
+// Custom package struct
+// Type is one of flatpak, appimage, binary, snap, homebrew, application, winget, executable or web
+type CustomPackage struct {
+	Type        string               `json:"type"`
+	AppID       string               `json:"appId"`
+	Namespace   string               `json:"namespace"`
+	Overrides   []string             `json:"overrides"`
+	Channel     string               `json:"channel"`
+	Launcher    string               `json:"launcher"`
+	Installer   string               `json:"installer"`
+	Uninstaller string               `json:"uninstaller"`
+	URL         string               `json:"url"`
+	Wrapper     string               `json:"wrapper"`
+	Arguments   *packaging.Arguments `json:"arguments"`
+	Source      *CustomSource        `json:"source"`
+}
+
+// Custom program struct
+type CustomProgram struct {
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Category    string           `json:"category"`
+	Tags        []string         `json:"tags"`
+	Folders     []string         `json:"folders"`
+	Website     string           `json:"website"`
+	IconURL     string           `json:"iconUrl"`
+	LogoURL     string           `json:"logoUrl"`
+	CoverURL    string           `json:"coverUrl"`
+	BannerURL   string           `json:"bannerUrl"`
+	HeroURL     string           `json:"heroUrl"`
+	Requires    []string         `json:"requires"`
+	Recommends  []string         `json:"recommends"`
+	Conflicts   []string         `json:"conflicts"`
+	Packages    []*CustomPackage `json:"packages"`
+}
+
+// Convert custom source into packaging source
+func (c *CustomSource) toSource() (*packaging.Source, error) {
+
+	if c == nil {
+		return nil, nil
+	}
+
+	var source *packaging.Source
+	switch c.Type {
+	case "github":
+		source = github.Release(c.Repository, c.Search)
+	case "gitlab":
+		source = gitlab.Release(c.Domain, c.Repository, c.Search)
+	case "forgejo":
+		source = forgejo.Release(c.Domain, c.Repository, c.Search)
+	case "website":
+		source = website.Release(c.URL, c.Prefix, c.Search)
+	case "link":
+		source = website.Link(c.URL)
+	default:
+		return nil, fmt.Errorf("unsupported source type: %s", c.Type)
+	}
+
+	source.Checksum = c.Checksum
+	source.ChecksumURL = c.ChecksumURL
+	source.Signature = c.Signature
+
+	return source, nil
+}
+
+// Convert custom package into packages of the respective type
+// Web type creates a package for each system with the wrapper program
+func (c *CustomPackage) toPackages(wrappers []*packaging.Program) ([]packaging.Package, error) {
+
+	packages := []packaging.Package{}
+
+	arguments := c.Arguments
+	if arguments == nil {
+		arguments = packaging.NoArguments()
+	}
+
+	source, err := c.Source.toSource()
+	if err != nil {
+		return packages, err
+	}
+
+	switch c.Type {
+	case "flatpak":
+		namespace := c.Namespace
+		if namespace == "" {
+			namespace = "system"
+		}
+		packages = append(packages, &linux.Flatpak{
+			AppID:     c.AppID,
+			Namespace: namespace,
+			Overrides: c.Overrides,
+			Arguments: arguments,
+		})
+	case "appimage":
+		packages = append(packages, &linux.AppImage{
+			AppID:     c.AppID,
+			Launcher:  c.Launcher,
+			Arguments: arguments,
+			Source:    source,
+		})
+	case "binary":
+		packages = append(packages, &linux.Binary{
+			AppID:     c.AppID,
+			Launcher:  c.Launcher,
+			Arguments: arguments,
+			Source:    source,
+		})
+	case "snap":
+		packages = append(packages, &linux.Snap{
+			AppID:     c.AppID,
+			Channel:   c.Channel,
+			Arguments: arguments,
+		})
+	case "homebrew":
+		packages = append(packages, &macos.Homebrew{
+			AppID:     c.AppID,
+			Launcher:  c.Launcher,
+			Arguments: arguments,
+		})
+	case "application":
+		packages = append(packages, &macos.Application{
+			AppID:     c.AppID,
+			Launcher:  c.Launcher,
+			Arguments: arguments,
+			Source:    source,
+		})
+	case "winget":
+		packages = append(packages, &windows.WinGet{
+			AppID:     c.AppID,
+			Launcher:  c.Launcher,
+			Arguments: arguments,
+		})
+	case "executable":
+		packages = append(packages, &windows.Executable{
+			AppID:       c.AppID,
+			Installer:   c.Installer,
+			Uninstaller: c.Uninstaller,
+			Launcher:    c.Launcher,
+			Arguments:   arguments,
+			Source:      source,
+		})
+	case "web":
+		var wrapper *packaging.Program
+		for _, program := range wrappers {
+			if program.ID == c.Wrapper {
+				wrapper = program
+				break
+			}
+		}
+		if wrapper == nil {
+			return packages, fmt.Errorf("could not find wrapper program: %s", c.Wrapper)
+		}
+
+		packages = append(packages, &linux.Web{
+			AppID:     c.AppID,
+			URL:       c.URL,
+			Wrapper:   wrapper,
+			Arguments: arguments,
+		}, &macos.Web{
+			AppID:     c.AppID,
+			URL:       c.URL,
+			Wrapper:   wrapper,
+			Arguments: arguments,
+		}, &windows.Web{
+			AppID:     c.AppID,
+			URL:       c.URL,
+			Wrapper:   wrapper,
+			Arguments: arguments,
+		})
+	default:
+		return packages, fmt.Errorf("unsupported package type: %s", c.Type)
+	}
+
+	return packages, nil
+}
+
+// Read custom programs from configuration file
+// Built-in programs are used to resolve wrappers of web packages
+func GetCustomPrograms(builtIn []*packaging.Program) ([]*packaging.Program, error) {
+
+	programs := []*packaging.Program{}
+
+	customFile := fs.ExpandPath("$APPLICATIONS/NiceDeck/custom/programs.json")
+	customPrograms := make([]CustomProgram, 0)
+	err := fs.ReadJSON(customFile, &customPrograms)
+	if err != nil {
+		return programs, err
+	}
+
+	for _, customProgram := range customPrograms {
+
+		if customProgram.ID == "" {
+			return programs, fmt.Errorf("custom program without ID: %s", customProgram.Name)
+		}
+
+		packages := []packaging.Package{}
+		for _, customPackage := range customProgram.Packages {
+			items, err := customPackage.toPackages(builtIn)
+			if err != nil {
+				return programs, fmt.Errorf("invalid custom program %s: %w", customProgram.ID, err)
+			}
+			packages = append(packages, items...)
+		}
+
+		tags := customProgram.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+
+		category := customProgram.Category
+		if category == "" {
+			category = "Custom"
+		}
+
+		folders := customProgram.Folders
+		if folders == nil {
+			folders = []string{}
+		}
+
+		programs = append(programs, &packaging.Program{
+			ID:          customProgram.ID,
+			Name:        customProgram.Name,
+			Description: customProgram.Description,
+			Category:    category,
+			Tags:        tags,
+			Flags:       []string{"--custom"},
+			Folders:     folders,
+			Website:     customProgram.Website,
+			IconURL:     customProgram.IconURL,
+			LogoURL:     customProgram.LogoURL,
+			CoverURL:    customProgram.CoverURL,
+			BannerURL:   customProgram.BannerURL,
+			HeroURL:     customProgram.HeroURL,
+			Requires:    customProgram.Requires,
+			Recommends:  customProgram.Recommends,
+			Conflicts:   customProgram.Conflicts,
+			Package:     packaging.Best(packages...),
+		})
+	}
+
+	return programs, nil
+}
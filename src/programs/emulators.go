@@ -10,6 +10,7 @@ import (
 	"github.com/mateussouzaweb/nicedeck/src/programs/forgejo"
 	"github.com/mateussouzaweb/nicedeck/src/programs/github"
 	"github.com/mateussouzaweb/nicedeck/src/programs/website"
+	"github.com/mateussouzaweb/nicedeck/src/retroarch/retroarch"
 )
 
 // Installer for Azahar
@@ -230,6 +231,7 @@ func Eden() *packaging.Program {
 		CoverURL:    assets.Cover("174b4233c093b0bf83e7c6fca65fae2a.png"),
 		BannerURL:   assets.Banner("f0ba96a506d7109bd0ec7c26bc957911.png"),
 		HeroURL:     assets.Hero("a960ee65d36125cfe5f126bd326ff75b.png"),
+		Conflicts:   []string{"ryujinx"},
 		Package: packaging.Best(&linux.AppImage{
 			AppID:     "eden",
 			Launcher:  "$EMULATORS/Eden/Eden.AppImage",
@@ -281,7 +283,7 @@ func Flycast() *packaging.Program {
 		Category:    "Emulators",
 		Tags:        []string{"Gaming", "Emulator"},
 		Flags:       []string{},
-		Folders:     []string{"$EMULATORS", "$STATE/Flycast", "$ROMS/DC", "$BIOS/DC"},
+		Folders:     []string{"$EMULATORS", "$STATE/Flycast", "$ROMS/DC", "$BIOS/dc"},
 		Website:     "https://github.com/flyinghead/flycast",
 		IconURL:     assets.Icon("858a2be748405d1cf063e97622abc791.png"),
 		LogoURL:     assets.Logo("b9b0c8b6beb69bd0c5a213b9422459ce.png"),
@@ -568,7 +570,7 @@ func Redream() *packaging.Program {
 		Category:    "Emulators",
 		Tags:        []string{"Gaming", "Emulator"},
 		Flags:       []string{},
-		Folders:     []string{"$EMULATORS", "$STATE/Redream", "$ROMS/DC", "$BIOS/DC"},
+		Folders:     []string{"$EMULATORS", "$STATE/Redream", "$ROMS/DC", "$BIOS/dc"},
 		Website:     "https://redream.io",
 		IconURL:     assets.Icon("5cc085288d7afc9d76f6aa846b7e5d5f.png"),
 		LogoURL:     assets.Logo("6c11cb78b7bbb5c22d5f5271b5494381.png"),
@@ -614,6 +616,31 @@ func Redream() *packaging.Program {
 	}
 }
 
+// Installer for RetroArch
+func RetroArch() *packaging.Program {
+	return &packaging.Program{
+		ID:          "retroarch",
+		Name:        "RetroArch",
+		Description: "Multi-system emulator with libretro cores",
+		Category:    "Emulators",
+		Tags:        []string{"Gaming", "Emulator"},
+		Flags:       []string{},
+		Folders: []string{
+			"$EMULATORS", "$STATE/RetroArch", "$BIOS/dc", "$BIOS/fbneo", "$BIOS/SATURN",
+			"$ROMS/ARCADE", "$ROMS/ATARI2600", "$ROMS/ATARI7800",
+			"$ROMS/GENESIS", "$ROMS/MSX", "$ROMS/NEOGEO", "$ROMS/NES",
+			"$ROMS/PCE", "$ROMS/SATURN", "$ROMS/SMS", "$ROMS/SNES",
+		},
+		Website:   "https://www.retroarch.com",
+		IconURL:   "",
+		LogoURL:   "",
+		CoverURL:  "",
+		BannerURL: "",
+		HeroURL:   "",
+		Package:   retroarch.GetPackage(),
+	}
+}
+
 // Installer for RPCS3
 func RPCS3() *packaging.Program {
 	return &packaging.Program{
@@ -682,6 +709,7 @@ func Ryujinx() *packaging.Program {
 		CoverURL:    assets.Cover("1633727e16b29e084edf3da658e392d0.png"),
 		BannerURL:   assets.Banner("9024d61574fcc58378aedbad631674f9.png"),
 		HeroURL:     assets.Hero("573185e7a57bcdcd68d7895cf83ffe66.png"),
+		Conflicts:   []string{"eden"},
 		Package: packaging.Best(&linux.AppImage{
 			AppID:     "ryujinx",
 			Launcher:  "$EMULATORS/Ryujinx/Ryujinx.AppImage",
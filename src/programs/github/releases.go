@@ -12,6 +12,13 @@ import (
 
 // Get asset direct download URL from the latest release available
 func GetAssetURL(repository string, search string) (string, error) {
+	url, _, err := GetAsset(repository, search)
+	return url, err
+}
+
+// Get asset and checksum download URLs from the latest release available
+// Checksum URL is empty when release does not include a checksum file
+func GetAsset(repository string, search string) (string, string, error) {
 
 	repository = strings.ReplaceAll(repository, "https://github.com/", "")
 	repository = strings.Trim(repository, "/")
@@ -35,7 +42,7 @@ func GetAssetURL(repository string, search string) (string, error) {
 	// Request latest releases
 	err := fs.RetrieveJSON(endpoint, &releases)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	// Sort releases by published date (newest first)
@@ -49,22 +56,42 @@ func GetAssetURL(repository string, search string) (string, error) {
 
 	// Check for matching asset
 	for _, release := range releases {
+		names := []string{}
+		for _, asset := range release.Assets {
+			names = append(names, asset.Name)
+		}
+
 		for _, asset := range release.Assets {
-			if searchRegex.MatchString(asset.Name) {
-				return asset.DownloadURL, nil
+			if !searchRegex.MatchString(asset.Name) {
+				continue
+			}
+
+			checksumURL := ""
+			if index := packaging.FindChecksumAsset(asset.Name, names); index != -1 {
+				checksumURL = release.Assets[index].DownloadURL
 			}
+
+			return asset.DownloadURL, checksumURL, nil
 		}
 	}
 
-	return "", fmt.Errorf("could not retrieve latest release asset")
+	return "", "", fmt.Errorf("could not retrieve latest release asset")
 }
 
 // Return packaging source from release
+// Checksum file of the release is used to verify the download when available
 func Release(repository string, search string) *packaging.Source {
-	return &packaging.Source{
+	source := &packaging.Source{
 		Format: packaging.FindFormat(search),
-		Resolver: func() (string, error) {
-			return GetAssetURL(repository, search)
-		},
 	}
+
+	source.Resolver = func() (string, error) {
+		url, checksumURL, err := GetAsset(repository, search)
+		if checksumURL != "" {
+			source.ChecksumURL = checksumURL
+		}
+		return url, err
+	}
+
+	return source
 }
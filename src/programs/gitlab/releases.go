@@ -12,6 +12,13 @@ import (
 
 // Get asset direct download URL from the latest release available
 func GetAssetURL(domain string, projectId string, search string) (string, error) {
+	url, _, err := GetAsset(domain, projectId, search)
+	return url, err
+}
+
+// Get asset and checksum download URLs from the latest release available
+// Checksum URL is empty when release does not include a checksum file
+func GetAsset(domain string, projectId string, search string) (string, string, error) {
 
 	domain = strings.Trim(domain, "/")
 	endpoint := fmt.Sprintf("%s/api/v4/projects/%s/releases", domain, projectId)
@@ -33,7 +40,7 @@ func GetAssetURL(domain string, projectId string, search string) (string, error)
 	// Request latest releases
 	err := fs.RetrieveJSON(endpoint, &releases)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	// Sort releases by published date (newest first)
@@ -47,25 +54,42 @@ func GetAssetURL(domain string, projectId string, search string) (string, error)
 
 	// Check for matching asset
 	for _, release := range releases {
+		names := []string{}
+		for _, link := range release.Assets.Links {
+			names = append(names, link.Name)
+		}
+
 		for _, link := range release.Assets.Links {
-			if searchRegex.MatchString(link.Name) {
-				return link.URL, nil
+			if !searchRegex.MatchString(link.Name) && !searchRegex.MatchString(link.URL) {
+				continue
 			}
-			if searchRegex.MatchString(link.URL) {
-				return link.URL, nil
+
+			checksumURL := ""
+			if index := packaging.FindChecksumAsset(link.Name, names); index != -1 {
+				checksumURL = release.Assets.Links[index].URL
 			}
+
+			return link.URL, checksumURL, nil
 		}
 	}
 
-	return "", fmt.Errorf("could not retrieve latest release asset")
+	return "", "", fmt.Errorf("could not retrieve latest release asset")
 }
 
 // Return packaging source from release
+// Checksum file of the release is used to verify the download when available
 func Release(domain string, projectId string, search string) *packaging.Source {
-	return &packaging.Source{
+	source := &packaging.Source{
 		Format: packaging.FindFormat(search),
-		Resolver: func() (string, error) {
-			return GetAssetURL(domain, projectId, search)
-		},
 	}
+
+	source.Resolver = func() (string, error) {
+		url, checksumURL, err := GetAsset(domain, projectId, search)
+		if checksumURL != "" {
+			source.ChecksumURL = checksumURL
+		}
+		return url, err
+	}
+
+	return source
 }
@@ -3,10 +3,12 @@ package programs
 import (
 	"fmt"
 
+	"github.com/mateussouzaweb/nicedeck/src/epic/epic"
 	"github.com/mateussouzaweb/nicedeck/src/esde/esde"
 	"github.com/mateussouzaweb/nicedeck/src/fs"
+	"github.com/mateussouzaweb/nicedeck/src/gog/gog"
+	"github.com/mateussouzaweb/nicedeck/src/heroic/heroic"
 	"github.com/mateussouzaweb/nicedeck/src/packaging"
-	"github.com/mateussouzaweb/nicedeck/src/packaging/linux"
 	"github.com/mateussouzaweb/nicedeck/src/packaging/macos"
 	"github.com/mateussouzaweb/nicedeck/src/packaging/proton"
 	"github.com/mateussouzaweb/nicedeck/src/packaging/windows"
@@ -149,26 +151,7 @@ func EpicGames() *packaging.Program {
 		CoverURL:    assets.Cover("67f56a2fe648cfdb82822bfdc360ef6a.png"),
 		BannerURL:   assets.Banner("02d7e610ae675ae3be88626d18fa7999.png"),
 		HeroURL:     assets.Hero("164fbf608021ece8933758ee2b28dd7d.png"),
-		Package: packaging.Best(&proton.Proton{
-			AppID:       "epic-games",
-			Installer:   "$DOWNLOADS/EpicGamesLauncherInstaller.msi",
-			Uninstaller: "$DOWNLOADS/EpicGamesLauncherInstaller.msi",
-			Launcher:    "$PROGRAMS/Epic Games/Launcher/Portal/Binaries/Win64/EpicGamesLauncher.exe",
-			Arguments: &packaging.Arguments{
-				Install:  []string{"-opengl"},
-				Remove:   []string{"-opengl"},
-				Shortcut: []string{"-opengl"},
-			},
-			Source: website.Link("https://launcher-public-service-prod06.ol.epicgames.com/launcher/api/installer/download/EpicGamesLauncherInstaller.msi"),
-		}, &macos.Homebrew{
-			AppID:     "epic-games",
-			Launcher:  "/Applications/Epic Games Launcher.app",
-			Arguments: packaging.NoArguments(),
-		}, &windows.WinGet{
-			AppID:     "EpicGames.EpicGamesLauncher",
-			Launcher:  "$PROGRAMS/Epic Games/Launcher/Portal/Binaries/Win64/EpicGamesLauncher.exe",
-			Arguments: packaging.NoArguments(),
-		}),
+		Package:     epic.GetPackage(),
 	}
 }
 
@@ -188,6 +171,7 @@ func ESDE() *packaging.Program {
 		CoverURL:    assets.Cover("21bd6ea21e43de6dc80e2bc8917f4ba3.png"),
 		BannerURL:   assets.Banner("67a900732336f1ce9d0c0496352fa9ab.png"),
 		HeroURL:     assets.Hero("9323f21f2098b7288267c785458548b2.png"),
+		Recommends:  []string{"retroarch"},
 		Package:     esde.GetPackage(),
 	}
 }
@@ -208,29 +192,7 @@ func GOGGalaxy() *packaging.Program {
 		CoverURL:    assets.Cover("c3d13ca6a5797b92dcaf18529d9d795f.png"),
 		BannerURL:   assets.Banner("5f77d1e72f72a5ea4cfd99b4a21e7fdd.png"),
 		HeroURL:     assets.Hero("01ccb68a74dd1edfbccbd76d86dbd51f.png"),
-		Package: packaging.Best(&proton.Proton{
-			AppID:       "gog-galaxy",
-			Installer:   "$DOWNLOADS/GOG_Galaxy_2.0.exe",
-			Uninstaller: "$PROGRAMS/GOG Galaxy/unins000.exe",
-			Launcher:    "$PROGRAMS/GOG Galaxy/GalaxyClient.exe",
-			Arguments: &packaging.Arguments{
-				Install:  []string{"/silent"},
-				Remove:   []string{"/SILENT"},
-				Shortcut: []string{},
-			},
-			Source: website.Release(
-				"https://www.gog.com/galaxy", "",
-				"https:*/download/GOG_Galaxy_2.0.exe",
-			),
-		}, &macos.Homebrew{
-			AppID:     "gog-galaxy",
-			Launcher:  "/Applications/GOG Galaxy.app",
-			Arguments: packaging.NoArguments(),
-		}, &windows.WinGet{
-			AppID:     "GOG.Galaxy",
-			Launcher:  "$PROGRAMS/GOG Galaxy/GalaxyClient.exe",
-			Arguments: packaging.NoArguments(),
-		}),
+		Package:     gog.GetPackage(),
 	}
 }
 
@@ -250,22 +212,7 @@ func HeroicGamesLauncher() *packaging.Program {
 		CoverURL:    assets.Cover("2b1c6cedeaf9571589e3dc9d51ba20e5.png"),
 		BannerURL:   assets.Banner("94e8e64cdefe77dcc168855c54f14acd.png"),
 		HeroURL:     assets.Hero("bee5ca2551bf346f067a3ac16057bc40.png"),
-		Package: packaging.Best(&linux.Flatpak{
-			AppID:     "com.heroicgameslauncher.hgl",
-			Namespace: "system",
-			Overrides: []string{
-				fmt.Sprintf("--filesystem=%s", fs.ExpandPath("$GAMES")),
-			},
-			Arguments: packaging.NoArguments(),
-		}, &macos.Homebrew{
-			AppID:     "heroic",
-			Launcher:  "/Applications/Heroic.app",
-			Arguments: packaging.NoArguments(),
-		}, &windows.WinGet{
-			AppID:     "HeroicGamesLauncher.HeroicGamesLauncher",
-			Launcher:  "$PROGRAMS/Heroic/Heroic.exe",
-			Arguments: packaging.NoArguments(),
-		}),
+		Package:     heroic.GetPackage(),
 	}
 }
 
@@ -2,6 +2,7 @@ package programs
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/mateussouzaweb/nicedeck/src/packaging"
 )
@@ -42,6 +43,7 @@ func GetPrograms() ([]*packaging.Program, error) {
 	programs = append(programs, PPSSPP())
 	programs = append(programs, ProtonPlus())
 	programs = append(programs, Redream())
+	programs = append(programs, RetroArch())
 	programs = append(programs, RockstarGamesLauncher())
 	programs = append(programs, RPCS3())
 	programs = append(programs, Ryujinx())
@@ -53,6 +55,20 @@ func GetPrograms() ([]*packaging.Program, error) {
 	programs = append(programs, Xemu())
 	programs = append(programs, Xenia())
 
+	// Merge custom programs from configuration file
+	// Custom programs replace built-in programs with the same ID
+	customPrograms, err := GetCustomPrograms(programs)
+	if err != nil {
+		return available, err
+	}
+
+	for _, customProgram := range customPrograms {
+		programs = slices.DeleteFunc(programs, func(program *packaging.Program) bool {
+			return program.ID == customProgram.ID
+		})
+		programs = append(programs, customProgram)
+	}
+
 	// Filter to return only available programs
 	for _, program := range programs {
 		if program.Package.Available() {
@@ -0,0 +1,162 @@
+package retroarch
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+	"github.com/mateussouzaweb/nicedeck/src/packaging/linux"
+	"github.com/mateussouzaweb/nicedeck/src/retroarch/retroarch"
+)
+
+// Retrieve path of the folder with RetroArch cores
+func GetCoresPath() string {
+
+	retroarchPackage := retroarch.GetPackage()
+
+	if cli.IsLinux() {
+		if _, ok := retroarchPackage.(*linux.Flatpak); ok {
+			return fs.ExpandPath("$VAR/org.libretro.RetroArch/config/retroarch/cores")
+		}
+		return fs.ExpandPath("$CONFIG/retroarch/cores")
+	} else if cli.IsMacOS() {
+		return fs.ExpandPath("$HOME/Library/Application Support/RetroArch/cores")
+	} else if cli.IsWindows() {
+		return filepath.Join(filepath.Dir(retroarchPackage.Executable()), "cores")
+	}
+
+	return ""
+}
+
+// Retrieve core file name for the running system
+func GetCoreFile(core string) string {
+	if cli.IsMacOS() {
+		return core + "_libretro.dylib"
+	} else if cli.IsWindows() {
+		return core + "_libretro.dll"
+	}
+
+	return core + "_libretro.so"
+}
+
+// Retrieve full path of the core file
+func GetCorePath(core string) string {
+	return filepath.Join(GetCoresPath(), GetCoreFile(core))
+}
+
+// Retrieve download URL of the core on libretro buildbot
+func GetCoreURL(core string) string {
+
+	system := ""
+	if cli.IsLinux() {
+		system = cli.ArchVariant("linux/x86_64", "linux/aarch64")
+	} else if cli.IsMacOS() {
+		system = cli.ArchVariant("apple/osx/x86_64", "apple/osx/arm64")
+	} else if cli.IsWindows() {
+		system = "windows/x86_64"
+	}
+
+	return fmt.Sprintf(
+		"https://buildbot.libretro.com/nightly/%s/latest/%s.zip",
+		system,
+		GetCoreFile(core),
+	)
+}
+
+// Validate core file by checking the binary format of the running system
+func ValidateCore(path string) error {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		errors.Join(err, file.Close())
+	}()
+
+	header := make([]byte, 4)
+	_, err = file.Read(header)
+	if err != nil {
+		return fmt.Errorf("invalid core file %s: %w", path, err)
+	}
+
+	valid := false
+	if cli.IsMacOS() {
+		valid = bytes.Equal(header, []byte{0xcf, 0xfa, 0xed, 0xfe}) ||
+			bytes.Equal(header, []byte{0xca, 0xfe, 0xba, 0xbe})
+	} else if cli.IsWindows() {
+		valid = bytes.HasPrefix(header, []byte("MZ"))
+	} else {
+		valid = bytes.Equal(header, []byte{0x7f, 'E', 'L', 'F'})
+	}
+
+	if !valid {
+		return fmt.Errorf("invalid core file %s: unexpected binary format", path)
+	}
+
+	return nil
+}
+
+// Check if core is installed and valid
+func CoreInstalled(core string) bool {
+	return ValidateCore(GetCorePath(core)) == nil
+}
+
+// Install core into RetroArch cores folder
+// Local stand-in files on custom cores folder are used before the buildbot
+// Stand-in can be the core file itself or the zip archive from buildbot
+func InstallCore(core string) error {
+
+	if CoreInstalled(core) {
+		return nil
+	}
+
+	coresPath := GetCoresPath()
+	coreFile := GetCoreFile(core)
+	corePath := filepath.Join(coresPath, coreFile)
+	localPath := fs.ExpandPath(filepath.Join("$APPLICATIONS/NiceDeck/custom/cores", coreFile))
+
+	// Install from local core file
+	exist, err := fs.FileExist(localPath)
+	if err != nil {
+		return err
+	} else if exist {
+		cli.Debug("Installing core %s from local file\n", core)
+		err := fs.CopyFile(localPath, corePath, true)
+		if err != nil {
+			return err
+		}
+
+		return ValidateCore(corePath)
+	}
+
+	// Install from local archive or download archive from buildbot
+	archivePath := localPath + ".zip"
+	exist, err = fs.FileExist(archivePath)
+	if err != nil {
+		return err
+	} else if !exist {
+		cli.Debug("Installing core %s from buildbot\n", core)
+		archivePath = filepath.Join(coresPath, coreFile+".zip")
+		err := fs.DownloadFile(GetCoreURL(core), archivePath, true)
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			errors.Join(err, fs.RemoveFile(archivePath))
+		}()
+	}
+
+	err = fs.ExtractZip(archivePath, coresPath)
+	if err != nil {
+		return fmt.Errorf("could not extract core %s: %w", core, err)
+	}
+
+	return ValidateCore(corePath)
+}
@@ -0,0 +1,26 @@
+package retroarch
+
+import (
+	"github.com/mateussouzaweb/nicedeck/src/packaging"
+	"github.com/mateussouzaweb/nicedeck/src/packaging/linux"
+	"github.com/mateussouzaweb/nicedeck/src/packaging/macos"
+	"github.com/mateussouzaweb/nicedeck/src/packaging/windows"
+)
+
+// Retrieve RetroArch package
+func GetPackage() packaging.Package {
+	return packaging.Best(&linux.Flatpak{
+		AppID:     "org.libretro.RetroArch",
+		Namespace: "system",
+		Overrides: []string{"--filesystem=host"},
+		Arguments: packaging.NoArguments(),
+	}, &macos.Homebrew{
+		AppID:     "retroarch",
+		Launcher:  "/Applications/RetroArch.app",
+		Arguments: packaging.NoArguments(),
+	}, &windows.WinGet{
+		AppID:     "Libretro.RetroArch",
+		Launcher:  "$HOMEDRIVE/RetroArch-Win64/retroarch.exe",
+		Arguments: packaging.NoArguments(),
+	})
+}
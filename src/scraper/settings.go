@@ -0,0 +1,65 @@
+package scraper
+
+import (
+	"slices"
+
+	"github.com/mateussouzaweb/nicedeck/src/fs"
+	"github.com/mateussouzaweb/nicedeck/src/scraper/steamgriddb"
+)
+
+// Settings struct
+// Images lists the image types scraped for new shortcuts:
+// icon, logo, cover, banner and hero
+// API key replaces the built-in SteamGridDB key when declared
+type Settings struct {
+	APIKey string   `json:"apiKey"`
+	Images []string `json:"images"`
+}
+
+// Retrieve settings file path
+func settingsPath() string {
+	return fs.ExpandPath("$APPLICATIONS/NiceDeck/scraper.json")
+}
+
+// Retrieve scraper settings, with every image type enabled by default
+func GetSettings() (*Settings, error) {
+
+	defaultImages := []string{"icon", "logo", "cover", "banner", "hero"}
+	settings := &Settings{
+		APIKey: "",
+		Images: defaultImages,
+	}
+
+	err := fs.ReadJSON(settingsPath(), settings)
+	if err != nil {
+		return settings, err
+	}
+
+	if settings.Images == nil {
+		settings.Images = defaultImages
+	}
+
+	return settings, nil
+}
+
+// Save scraper settings
+func SetSettings(settings *Settings) error {
+	return fs.WriteJSON(settingsPath(), settings)
+}
+
+// Transform settings into options for given search term
+func (s *Settings) ToOptions(search string) *Options {
+	return ToOptions(
+		search,
+		slices.Contains(s.Images, "icon"),
+		slices.Contains(s.Images, "logo"),
+		slices.Contains(s.Images, "cover"),
+		slices.Contains(s.Images, "banner"),
+		slices.Contains(s.Images, "hero"),
+	)
+}
+
+// Apply settings to the scraper provider
+func (s *Settings) Apply() {
+	steamgriddb.SetAPIKey(s.APIKey)
+}
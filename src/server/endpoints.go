@@ -15,9 +15,12 @@ import (
 
 	"github.com/mateussouzaweb/nicedeck/frontend"
 	"github.com/mateussouzaweb/nicedeck/src/cli"
+	"github.com/mateussouzaweb/nicedeck/src/desktop"
+	files "github.com/mateussouzaweb/nicedeck/src/fs"
 	"github.com/mateussouzaweb/nicedeck/src/management"
 	"github.com/mateussouzaweb/nicedeck/src/packaging"
 	"github.com/mateussouzaweb/nicedeck/src/platforms"
+	"github.com/mateussouzaweb/nicedeck/src/platforms/configure"
 	"github.com/mateussouzaweb/nicedeck/src/platforms/console"
 	"github.com/mateussouzaweb/nicedeck/src/platforms/native"
 	"github.com/mateussouzaweb/nicedeck/src/platforms/state"
@@ -119,6 +122,59 @@ func syncLibrary(context *Context) error {
 	return context.Status(200).JSON(result)
 }
 
+// Import desktop data
+type ImportDesktopData struct {
+	Enabled bool     `json:"enabled"`
+	Folders []string `json:"folders"`
+	Entries []string `json:"entries"`
+}
+
+// Import desktop result
+type ImportDesktopResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+// Import desktop action
+func importDesktop(context *Context) error {
+
+	result := ImportDesktopResult{}
+
+	// Bind data
+	data := ImportDesktopData{}
+	err := context.Bind(&data)
+	if err != nil {
+		result.Status = "ERROR"
+		result.Error = err.Error()
+		return context.Status(400).JSON(result)
+	}
+
+	// Save import options
+	options := &desktop.Import{
+		Enabled: data.Enabled,
+		Folders: data.Folders,
+		Entries: data.Entries,
+	}
+
+	err = management.ConfigureDesktopImport(options)
+	if err != nil {
+		result.Status = "ERROR"
+		result.Error = err.Error()
+		return context.Status(400).JSON(result)
+	}
+
+	// Sync user library to apply import
+	err = management.SyncLibrary()
+	if err != nil {
+		result.Status = "ERROR"
+		result.Error = err.Error()
+		return context.Status(400).JSON(result)
+	}
+
+	result.Status = "OK"
+	return context.Status(200).JSON(result)
+}
+
 // List platforms result
 type ListProgramsResult struct {
 	Status string               `json:"status"`
@@ -476,6 +532,47 @@ func modifyShortcut(context *Context) error {
 	return context.Status(200).JSON(result)
 }
 
+// List outdated programs result
+type ListOutdatedResult struct {
+	Status string              `json:"status"`
+	Error  string              `json:"error"`
+	Data   []*packaging.Update `json:"data"`
+}
+
+// List outdated programs action
+func listOutdated(context *Context) error {
+
+	result := ListOutdatedResult{}
+
+	data, err := management.CheckProgramsUpdates()
+	if err != nil {
+		result.Status = "ERROR"
+		result.Error = err.Error()
+		return context.Status(400).JSON(result)
+	}
+
+	result.Status = "OK"
+	result.Data = data
+	return context.Status(200).JSON(result)
+}
+
+// List downloads result
+type ListDownloadsResult struct {
+	Status string           `json:"status"`
+	Error  string           `json:"error"`
+	Data   []files.Download `json:"data"`
+}
+
+// List progress of active downloads action
+func listDownloads(context *Context) error {
+
+	result := ListDownloadsResult{}
+	result.Status = "OK"
+	result.Data = files.GetDownloads()
+
+	return context.Status(200).JSON(result)
+}
+
 // Install programs data
 type InstallProgramsData struct {
 	Programs    []string `json:"programs"`
@@ -517,6 +614,43 @@ func installPrograms(context *Context) error {
 	return context.Status(200).JSON(result)
 }
 
+// Rollback program data
+type RollbackProgramData struct {
+	Program string `json:"program"`
+}
+
+// Rollback program result
+type RollbackProgramResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+// Rollback program action
+func rollbackProgram(context *Context) error {
+
+	result := RollbackProgramResult{}
+
+	// Bind data
+	data := RollbackProgramData{}
+	err := context.Bind(&data)
+	if err != nil {
+		result.Status = "ERROR"
+		result.Error = err.Error()
+		return context.Status(400).JSON(result)
+	}
+
+	// Restore previous version of program
+	err = management.RollbackProgram(data.Program)
+	if err != nil {
+		result.Status = "ERROR"
+		result.Error = err.Error()
+		return context.Status(400).JSON(result)
+	}
+
+	result.Status = "OK"
+	return context.Status(200).JSON(result)
+}
+
 // Remove programs data
 type RemoveProgramsData struct {
 	Programs    []string `json:"programs"`
@@ -558,6 +692,52 @@ func removePrograms(context *Context) error {
 	return context.Status(200).JSON(result)
 }
 
+// Configure programs data
+type ConfigureProgramsData struct {
+	Action      string   `json:"action"`
+	Programs    []string `json:"programs"`
+	Preferences []string `json:"preferences"`
+}
+
+// Configure programs result
+type ConfigureProgramsResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+// Configure programs action
+func configurePrograms(context *Context) error {
+
+	result := ConfigureProgramsResult{}
+
+	// Bind data
+	data := ConfigureProgramsData{}
+	err := context.Bind(&data)
+	if err != nil {
+		result.Status = "ERROR"
+		result.Error = err.Error()
+		return context.Status(400).JSON(result)
+	}
+
+	if data.Action == "" {
+		data.Action = "apply"
+	}
+
+	// Apply or revert configuration of programs in the list
+	options := configure.ToOptions(data.Action, data.Programs, data.Preferences)
+	err = management.ConfigurePrograms(options)
+	if err != nil {
+		result.Status = "ERROR"
+		result.Error = err.Error()
+		return context.Status(400).JSON(result)
+	}
+
+	cli.Printf(cli.ColorSuccess, "Process finished!\n")
+
+	result.Status = "OK"
+	return context.Status(200).JSON(result)
+}
+
 // List state result
 type ListStateResult struct {
 	Status string                  `json:"status"`
@@ -607,6 +787,55 @@ func listState(context *Context) error {
 	return context.Status(http.StatusOK).JSON(result)
 }
 
+// State diff result
+type StateDiffResult struct {
+	Status string        `json:"status"`
+	Error  string        `json:"error"`
+	Data   []*state.Diff `json:"data"`
+}
+
+// State diff action
+func stateDiff(context *Context) error {
+
+	result := StateDiffResult{}
+
+	// Bind data
+	query := context.Request.URL.Query()
+	action := query.Get("action")
+	platformsParam := query.Get("platforms")
+	preferencesParam := query.Get("preferences")
+
+	// Create and validate options
+	platforms := strings.Split(platformsParam, ",")
+	preferences := strings.Split(preferencesParam, ",")
+	options := state.ToOptions(action, platforms, preferences)
+
+	if options.Action == "" {
+		err := fmt.Errorf("action is required")
+		result.Status = "ERROR"
+		result.Error = err.Error()
+		return context.Status(400).JSON(result)
+	}
+	if len(options.Platforms) == 0 {
+		err := fmt.Errorf("platform list is required")
+		result.Status = "ERROR"
+		result.Error = err.Error()
+		return context.Status(400).JSON(result)
+	}
+
+	// Retrieve diff results
+	data, err := management.GetStateDiff(options)
+	if err != nil {
+		result.Status = "ERROR"
+		result.Error = err.Error()
+		return context.Status(400).JSON(result)
+	}
+
+	result.Status = "OK"
+	result.Data = data
+	return context.Status(http.StatusOK).JSON(result)
+}
+
 // Backup state data
 type BackupStateData struct {
 	Platforms   []string `json:"platforms"`
@@ -685,6 +914,44 @@ func restoreState(context *Context) error {
 	return context.Status(200).JSON(result)
 }
 
+// Check BIOS result
+type CheckBIOSResult struct {
+	Status string                `json:"status"`
+	Error  string                `json:"error"`
+	Data   []*console.BIOSReport `json:"data"`
+}
+
+// Check BIOS action
+func checkBIOS(context *Context) error {
+
+	result := CheckBIOSResult{}
+
+	// Bind data
+	query := context.Request.URL.Query()
+	platformsParam := query.Get("platforms")
+	preferencesParam := query.Get("preferences")
+
+	// Empty platform list checks every platform
+	include := []string{}
+	if platformsParam != "" {
+		include = strings.Split(platformsParam, ",")
+	}
+
+	// Retrieve BIOS reports
+	preferences := strings.Split(preferencesParam, ",")
+	options := platforms.ToOptions(include, preferences)
+	data, err := management.CheckBIOS(options)
+	if err != nil {
+		result.Status = "ERROR"
+		result.Error = err.Error()
+		return context.Status(400).JSON(result)
+	}
+
+	result.Status = "OK"
+	result.Data = data
+	return context.Status(http.StatusOK).JSON(result)
+}
+
 // Process ROMs data
 type ProcessROMsData struct {
 	Platforms   []string `json:"platforms"`
@@ -724,6 +991,173 @@ func processROMs(context *Context) error {
 	return context.Status(200).JSON(result)
 }
 
+// ROMs report result
+type ReportROMsResult struct {
+	Status string            `json:"status"`
+	Error  string            `json:"error"`
+	Data   *platforms.Report `json:"data"`
+}
+
+// ROMs report action
+func reportROMs(context *Context) error {
+
+	result := ReportROMsResult{}
+
+	// Bind data
+	query := context.Request.URL.Query()
+	platformsParam := query.Get("platforms")
+	preferencesParam := query.Get("preferences")
+
+	// Empty platform list reports every platform
+	include := []string{}
+	if platformsParam != "" {
+		include = strings.Split(platformsParam, ",")
+	}
+
+	// Generate report
+	preferences := strings.Split(preferencesParam, ",")
+	options := platforms.ToOptions(include, preferences)
+	data, err := management.GetPlatformsReport(options)
+	if err != nil {
+		result.Status = "ERROR"
+		result.Error = err.Error()
+		return context.Status(400).JSON(result)
+	}
+
+	result.Status = "OK"
+	result.Data = data
+	return context.Status(http.StatusOK).JSON(result)
+}
+
+// Prune ROMs data
+type PruneROMsData struct {
+	Platforms   []string `json:"platforms"`
+	Preferences []string `json:"preferences"`
+}
+
+// Prune ROMs result
+type PruneROMsResult struct {
+	Status string            `json:"status"`
+	Error  string            `json:"error"`
+	Data   *platforms.Report `json:"data"`
+}
+
+// Prune ROMs action (to remove orphan shortcuts)
+func pruneROMs(context *Context) error {
+
+	result := PruneROMsResult{}
+
+	// Bind data
+	data := PruneROMsData{}
+	err := context.Bind(&data)
+	if err != nil {
+		result.Status = "ERROR"
+		result.Error = err.Error()
+		return context.Status(400).JSON(result)
+	}
+
+	// Generate report and remove orphans
+	options := platforms.ToOptions(data.Platforms, data.Preferences)
+	report, err := management.GetPlatformsReport(options)
+	if err != nil {
+		result.Status = "ERROR"
+		result.Error = err.Error()
+		return context.Status(400).JSON(result)
+	}
+
+	err = management.PruneOrphanShortcuts(report)
+	if err != nil {
+		result.Status = "ERROR"
+		result.Error = err.Error()
+		return context.Status(400).JSON(result)
+	}
+
+	result.Status = "OK"
+	result.Data = report
+	return context.Status(200).JSON(result)
+}
+
+// List ROM overrides result
+type ListROMOverridesResult struct {
+	Status string              `json:"status"`
+	Error  string              `json:"error"`
+	Data   []*console.Override `json:"data"`
+}
+
+// List ROM overrides action
+func listROMOverrides(context *Context) error {
+
+	result := ListROMOverridesResult{}
+
+	data, err := management.GetROMOverrides()
+	if err != nil {
+		result.Status = "ERROR"
+		result.Error = err.Error()
+		return context.Status(400).JSON(result)
+	}
+
+	result.Status = "OK"
+	result.Data = data
+	return context.Status(200).JSON(result)
+}
+
+// Modify ROM override data
+type ModifyROMOverrideData struct {
+	Action       string   `json:"action"`
+	RelativePath string   `json:"relativePath"`
+	Emulator     string   `json:"emulator"`
+	Arguments    string   `json:"arguments"`
+	Environment  []string `json:"environment"`
+}
+
+// Modify ROM override result
+type ModifyROMOverrideResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+// Modify ROM override action
+func modifyROMOverride(context *Context) error {
+
+	result := ModifyROMOverrideResult{}
+
+	// Bind data
+	data := ModifyROMOverrideData{}
+	err := context.Bind(&data)
+	if err != nil {
+		result.Status = "ERROR"
+		result.Error = err.Error()
+		return context.Status(400).JSON(result)
+	}
+
+	if data.RelativePath == "" {
+		result.Status = "ERROR"
+		result.Error = "ROM path is required"
+		return context.Status(400).JSON(result)
+	}
+
+	// Set or remove override of the ROM
+	if data.Action == "delete" {
+		err = management.RemoveROMOverride(data.RelativePath)
+	} else {
+		err = management.SetROMOverride(&console.Override{
+			RelativePath: data.RelativePath,
+			Emulator:     data.Emulator,
+			Arguments:    data.Arguments,
+			Environment:  data.Environment,
+		})
+	}
+
+	if err != nil {
+		result.Status = "ERROR"
+		result.Error = err.Error()
+		return context.Status(400).JSON(result)
+	}
+
+	result.Status = "OK"
+	return context.Status(200).JSON(result)
+}
+
 // Scrape data result
 type ScrapeDataResult struct {
 	Status string                `json:"status"`
@@ -887,22 +1321,33 @@ func Setup(developmentMode bool, shutdown chan bool) error {
 	})
 
 	// Specific routes
+	Add("GET", "/api/programs/outdated", listOutdated)
 	Add("GET", "/api/programs", listPrograms)
+	Add("GET", "/api/downloads", listDownloads)
 	Add("GET", "/api/platforms", listPlatforms)
+	Add("GET", "/api/bios", checkBIOS)
+	Add("GET", "/api/state/diff", stateDiff)
 	Add("GET", "/api/state", listState)
 	Add("GET", "/api/shortcuts", listShortcuts)
 	Add("GET", "/api/scrape", scrapeData)
 	Add("POST", "/api/library/load", loadLibrary)
 	Add("POST", "/api/library/save", saveLibrary)
 	Add("POST", "/api/library/sync", syncLibrary)
+	Add("POST", "/api/library/desktop", importDesktop)
 	Add("POST", "/api/shortcut/launch", launchShortcut)
 	Add("POST", "/api/shortcut/create", createShortcut)
 	Add("POST", "/api/shortcut/add", addShortcut)
 	Add("POST", "/api/shortcut/modify", modifyShortcut)
 	Add("POST", "/api/programs/install", installPrograms)
+	Add("POST", "/api/programs/rollback", rollbackProgram)
 	Add("POST", "/api/programs/remove", removePrograms)
+	Add("POST", "/api/programs/configure", configurePrograms)
 	Add("POST", "/api/state/backup", backupState)
 	Add("POST", "/api/state/restore", restoreState)
+	Add("GET", "/api/roms/report", reportROMs)
+	Add("POST", "/api/roms/prune", pruneROMs)
+	Add("GET", "/api/roms/overrides", listROMOverrides)
+	Add("POST", "/api/roms/override", modifyROMOverride)
 	Add("POST", "/api/roms", processROMs)
 	Add("POST", "/api/link/open", openLink)
 
@@ -20,7 +20,9 @@ func FromUint(value uint) string {
 // Generate shortcut ID
 // - Used as ID in library shortcut
 // - Used as AppID in Steam shortcuts.vdf
+// - On portable mode, ID does not change with the drive mount point
 func GenerateID(name string, executable string) string {
+	executable = replacePrefix(executable, portableRoot(), portablePlaceholder)
 	uniqueName := []byte(executable + name)
 	result := crc32.ChecksumIEEE(uniqueName) | 0x80000000
 	return FromUint(uint(result))
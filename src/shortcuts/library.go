@@ -20,6 +20,7 @@ type History struct {
 
 // Library struct
 type Library struct {
+	Root         string      `json:"root"`
 	DatabasePath string      `json:"databasePath"`
 	ImagesPath   string      `json:"imagesPath"`
 	Shortcuts    []*Shortcut `json:"shortcuts"`
@@ -51,6 +52,8 @@ func (l *Library) Load() error {
 	// Reset and fill basic information
 	l.Shortcuts = make([]*Shortcut, 0)
 	l.History = make([]*History, 0)
+	databasePath := l.DatabasePath
+	imagesPath := l.ImagesPath
 
 	// Read database file content
 	err := fs.ReadJSON(l.DatabasePath, &l)
@@ -58,6 +61,23 @@ func (l *Library) Load() error {
 		return err
 	}
 
+	// Paths are always based on current location of the database
+	l.DatabasePath = databasePath
+	l.ImagesPath = imagesPath
+
+	// On portable mode, expand paths relative to the portable root
+	// When the mount point changed, shortcuts are updated to sync new paths
+	root := portableRoot()
+	if root != "" {
+		for _, shortcut := range l.Shortcuts {
+			shortcut.ReplacePath(portablePlaceholder, root)
+		}
+		if l.Root != "" && l.Root != root {
+			l.Relocate(l.Root, root)
+		}
+	}
+	l.Root = root
+
 	// Read database modified time and use as timestamp reference
 	// Process will fill shortcut timestamps if missing
 	timestamp, err := fs.ModificationTime(l.DatabasePath)
@@ -90,7 +110,18 @@ func (l *Library) Save() error {
 	}
 
 	// Save database state to file
-	err = fs.WriteJSON(l.DatabasePath, l)
+	// On portable mode, paths are stored relative to the portable root
+	database := *l
+	if l.Root != "" {
+		database.Shortcuts = make([]*Shortcut, 0, len(l.Shortcuts))
+		for _, shortcut := range l.Shortcuts {
+			relative := *shortcut
+			relative.ReplacePath(l.Root, portablePlaceholder)
+			database.Shortcuts = append(database.Shortcuts, &relative)
+		}
+	}
+
+	err = fs.WriteJSON(l.DatabasePath, database)
 	if err != nil {
 		return err
 	}
@@ -98,6 +129,27 @@ func (l *Library) Save() error {
 	return nil
 }
 
+// Relocate shortcuts after the portable root has been moved
+// Updates are recorded in history to rewrite paths in synced libraries
+func (l *Library) Relocate(from string, to string) {
+
+	cli.Printf(cli.ColorNotice, "Portable root moved from %s to %s, updating shortcuts...\n", from, to)
+	timestamp := time.Now().UTC().Unix()
+
+	for _, shortcut := range l.Shortcuts {
+		original := *shortcut
+		original.ReplacePath(to, from)
+
+		shortcut.Timestamp = timestamp
+		l.History = append(l.History, &History{
+			Action:   "updated",
+			Original: &original,
+			Result:   shortcut,
+		})
+	}
+
+}
+
 // Retrieve all shortcuts in the library
 func (l *Library) All() []*Shortcut {
 	return l.Shortcuts
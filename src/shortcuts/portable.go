@@ -0,0 +1,84 @@
+package shortcuts
+
+import (
+	"os"
+	"strings"
+)
+
+// Placeholder used to store paths relative to the portable root
+const portablePlaceholder = "$PORTABLE"
+
+// Retrieve current portable root, empty when portable mode is disabled
+func portableRoot() string {
+	return os.Getenv("PORTABLE")
+}
+
+// Remove trailing path separator of root folders, like drive roots
+func trimSeparator(path string) string {
+	if len(path) > 1 && strings.ContainsAny(path[len(path)-1:], `/\`) {
+		return path[:len(path)-1]
+	}
+
+	return path
+}
+
+// Replace path prefix on value with given replacement
+// Prefix is only replaced when not preceded by a letter or digit and when
+// followed by a path separator, quote, space or end of value, so similar
+// folder names are not affected
+// Trailing separator of prefix and replacement, like on E:\, is kept on value
+func replacePrefix(value string, from string, to string) string {
+
+	from = trimSeparator(from)
+	to = trimSeparator(to)
+
+	if from == "" || from == to || !strings.Contains(value, from) {
+		return value
+	}
+
+	result := strings.Builder{}
+	offset := 0
+	for {
+		index := strings.Index(value[offset:], from)
+		if index < 0 {
+			result.WriteString(value[offset:])
+			break
+		}
+
+		index += offset
+		end := index + len(from)
+		result.WriteString(value[offset:index])
+
+		before := index == 0 || !isAlphanumeric(value[index-1])
+		after := end == len(value) || strings.ContainsAny(value[end:end+1], `/\"' `)
+		if before && after {
+			result.WriteString(to)
+		} else {
+			result.WriteString(from)
+		}
+
+		offset = end
+	}
+
+	return result.String()
+}
+
+// Check if character is a letter or digit
+func isAlphanumeric(char byte) bool {
+	return (char >= 'a' && char <= 'z') ||
+		(char >= 'A' && char <= 'Z') ||
+		(char >= '0' && char <= '9')
+}
+
+// Replace path prefix on all path related fields of the shortcut
+func (s *Shortcut) ReplacePath(from string, to string) {
+	s.StartDirectory = replacePrefix(s.StartDirectory, from, to)
+	s.Executable = replacePrefix(s.Executable, from, to)
+	s.LaunchOptions = replacePrefix(s.LaunchOptions, from, to)
+	s.RelativePath = replacePrefix(s.RelativePath, from, to)
+	s.IconPath = replacePrefix(s.IconPath, from, to)
+	s.LogoPath = replacePrefix(s.LogoPath, from, to)
+	s.CoverPath = replacePrefix(s.CoverPath, from, to)
+	s.BannerPath = replacePrefix(s.BannerPath, from, to)
+	s.HeroPath = replacePrefix(s.HeroPath, from, to)
+}
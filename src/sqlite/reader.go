@@ -0,0 +1,488 @@
+package sqlite
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+// Page types of b-tree pages
+const (
+	pageInteriorTable byte = 0x05
+	pageLeafTable     byte = 0x0D
+)
+
+// Error when table does not exist in the database
+var ErrTableNotFound = errors.New("table not found")
+
+// Row of table with values by column name
+// Values can be nil, int64, float64, string or []byte
+type Row map[string]any
+
+// Magic numbers of WAL file header, the last bit defines checksum byte order
+const (
+	walMagicLittleEndian uint32 = 0x377f0682
+	walMagicBigEndian    uint32 = 0x377f0683
+)
+
+// Database struct
+// This is a minimal read-only reader for SQLite database files
+// Only table b-trees are supported, committed WAL frames are applied when
+// the WAL file exists, like when the database is still open by other program
+type Database struct {
+	data       []byte
+	pageSize   int
+	usableSize int
+}
+
+// Open database file from path, applying changes from WAL file when present
+func Open(path string) (*Database, error) {
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	database, err := Read(content)
+	if err != nil {
+		return nil, err
+	}
+
+	wal, err := os.ReadFile(path + "-wal")
+	if os.IsNotExist(err) {
+		return database, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	err = database.ApplyWAL(wal)
+	if err != nil {
+		return nil, err
+	}
+
+	return database, nil
+}
+
+// Calculate cumulative WAL checksum of data with the given byte order
+func walChecksum(order binary.ByteOrder, data []byte, s0 uint32, s1 uint32) (uint32, uint32) {
+	for index := 0; index+8 <= len(data); index += 8 {
+		s0 += order.Uint32(data[index:]) + s1
+		s1 += order.Uint32(data[index+4:]) + s0
+	}
+
+	return s0, s1
+}
+
+// Apply committed frames of WAL content into database pages
+// Frames after the last valid commit frame are incomplete and ignored
+func (d *Database) ApplyWAL(wal []byte) error {
+
+	if len(wal) < 32 {
+		return nil
+	}
+
+	var order binary.ByteOrder
+	switch binary.BigEndian.Uint32(wal[0:4]) {
+	case walMagicLittleEndian:
+		order = binary.LittleEndian
+	case walMagicBigEndian:
+		order = binary.BigEndian
+	default:
+		return errors.New("invalid SQLite WAL file")
+	}
+
+	// WAL file from another database configuration is ignored
+	pageSize := int(binary.BigEndian.Uint32(wal[8:12]))
+	if pageSize != d.pageSize {
+		return nil
+	}
+
+	salt := wal[16:24]
+	s0, s1 := walChecksum(order, wal[0:24], 0, 0)
+	if s0 != binary.BigEndian.Uint32(wal[24:28]) || s1 != binary.BigEndian.Uint32(wal[28:32]) {
+		return nil
+	}
+
+	// Validate frames and keep pages of committed transactions
+	pages := map[int][]byte{}
+	pending := map[int][]byte{}
+	size := 0
+
+	for offset := 32; offset+24+pageSize <= len(wal); offset += 24 + pageSize {
+		header := wal[offset : offset+24]
+		page := wal[offset+24 : offset+24+pageSize]
+
+		if !bytes.Equal(header[8:16], salt) {
+			break
+		}
+
+		s0, s1 = walChecksum(order, header[0:8], s0, s1)
+		s0, s1 = walChecksum(order, page, s0, s1)
+		if s0 != binary.BigEndian.Uint32(header[16:20]) || s1 != binary.BigEndian.Uint32(header[20:24]) {
+			break
+		}
+
+		number := int(binary.BigEndian.Uint32(header[0:4]))
+		pending[number] = page
+
+		// Commit frame contains the size of database in pages after commit
+		commit := int(binary.BigEndian.Uint32(header[4:8]))
+		if commit > 0 {
+			for number, page := range pending {
+				pages[number] = page
+			}
+			pending = map[int][]byte{}
+			size = commit
+		}
+	}
+
+	if size == 0 {
+		return nil
+	}
+
+	// Resize database to committed size and replace changed pages
+	data := make([]byte, size*d.pageSize)
+	copy(data, d.data)
+
+	for number, page := range pages {
+		if number < 1 || number > size {
+			continue
+		}
+		copy(data[(number-1)*d.pageSize:], page)
+	}
+
+	d.data = data
+	return nil
+}
+
+// Read database from content
+func Read(content []byte) (*Database, error) {
+
+	if len(content) < 100 || !bytes.HasPrefix(content, []byte("SQLite format 3\x00")) {
+		return nil, errors.New("invalid SQLite database")
+	}
+
+	// Page size of 1 represents 65536
+	pageSize := int(binary.BigEndian.Uint16(content[16:18]))
+	if pageSize == 1 {
+		pageSize = 65536
+	}
+
+	database := &Database{
+		data:       content,
+		pageSize:   pageSize,
+		usableSize: pageSize - int(content[20]),
+	}
+
+	return database, nil
+}
+
+// Retrieve page content with given number
+func (d *Database) page(number int) ([]byte, error) {
+	start := (number - 1) * d.pageSize
+	end := start + d.pageSize
+	if number < 1 || end > len(d.data) {
+		return nil, fmt.Errorf("invalid page number: %d", number)
+	}
+
+	return d.data[start:end], nil
+}
+
+// Read variable length integer from data and return value and size
+func readVarint(data []byte) (int64, int) {
+	var value int64
+	for index := 0; index < 9 && index < len(data); index++ {
+		if index == 8 {
+			return value<<8 | int64(data[index]), 9
+		}
+		value = value<<7 | int64(data[index]&0x7F)
+		if data[index]&0x80 == 0 {
+			return value, index + 1
+		}
+	}
+
+	return value, len(data)
+}
+
+// Read payload of cell, following overflow pages when necessary
+func (d *Database) payload(cell []byte, size int) ([]byte, error) {
+
+	// Determine local payload size
+	local := size
+	maxLocal := d.usableSize - 35
+	if size > maxLocal {
+		minLocal := ((d.usableSize-12)*32)/255 - 23
+		local = minLocal + (size-minLocal)%(d.usableSize-4)
+		if local > maxLocal {
+			local = minLocal
+		}
+	}
+
+	if len(cell) < local {
+		return nil, errors.New("corrupted cell payload")
+	}
+
+	result := make([]byte, 0, size)
+	result = append(result, cell[:local]...)
+	if local == size {
+		return result, nil
+	}
+
+	// Read overflow pages chain
+	next := int(binary.BigEndian.Uint32(cell[local : local+4]))
+	for next != 0 && len(result) < size {
+		page, err := d.page(next)
+		if err != nil {
+			return nil, err
+		}
+
+		remaining := size - len(result)
+		chunk := min(remaining, d.usableSize-4)
+		result = append(result, page[4:4+chunk]...)
+		next = int(binary.BigEndian.Uint32(page[0:4]))
+	}
+
+	return result, nil
+}
+
+// Decode record payload into list of values
+func decodeRecord(payload []byte) ([]any, error) {
+
+	values := []any{}
+	headerSize, offset := readVarint(payload)
+	if int(headerSize) > len(payload) {
+		return values, errors.New("corrupted record header")
+	}
+
+	types := []int64{}
+	for offset < int(headerSize) {
+		serial, size := readVarint(payload[offset:])
+		types = append(types, serial)
+		offset += size
+	}
+
+	position := int(headerSize)
+	for _, serial := range types {
+
+		// Determine content size from serial type
+		size := 0
+		switch {
+		case serial >= 1 && serial <= 4:
+			size = int(serial)
+		case serial == 5:
+			size = 6
+		case serial == 6 || serial == 7:
+			size = 8
+		case serial >= 12:
+			size = int(serial-12) / 2
+		}
+
+		if position+size > len(payload) {
+			return values, errors.New("corrupted record content")
+		}
+
+		content := payload[position : position+size]
+		position += size
+
+		switch {
+		case serial == 0:
+			values = append(values, nil)
+		case serial >= 1 && serial <= 6:
+			// Big-endian two's complement integer
+			value := int64(int8(content[0]))
+			for _, item := range content[1:] {
+				value = value<<8 | int64(item)
+			}
+			values = append(values, value)
+		case serial == 7:
+			values = append(values, math.Float64frombits(binary.BigEndian.Uint64(content)))
+		case serial == 8:
+			values = append(values, int64(0))
+		case serial == 9:
+			values = append(values, int64(1))
+		case serial >= 12 && serial%2 == 0:
+			values = append(values, append([]byte{}, content...))
+		case serial >= 13:
+			values = append(values, string(content))
+		default:
+			values = append(values, nil)
+		}
+	}
+
+	return values, nil
+}
+
+// Walk table b-tree from root page and call handler for each record
+func (d *Database) walk(number int, handler func(rowID int64, values []any) error) error {
+
+	page, err := d.page(number)
+	if err != nil {
+		return err
+	}
+
+	// First page contains the database header
+	header := page
+	if number == 1 {
+		header = page[100:]
+	}
+
+	pageType := header[0]
+	cells := int(binary.BigEndian.Uint16(header[3:5]))
+	pointers := header[8:]
+	if pageType == pageInteriorTable {
+		pointers = header[12:]
+	} else if pageType != pageLeafTable {
+		return fmt.Errorf("unsupported page type: %d", pageType)
+	}
+
+	for index := range cells {
+		offset := int(binary.BigEndian.Uint16(pointers[index*2 : index*2+2]))
+		cell := page[offset:]
+
+		// Interior cells only point to child pages
+		if pageType == pageInteriorTable {
+			child := int(binary.BigEndian.Uint32(cell[0:4]))
+			err := d.walk(child, handler)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		size, length := readVarint(cell)
+		cell = cell[length:]
+		rowID, length := readVarint(cell)
+		cell = cell[length:]
+
+		payload, err := d.payload(cell, int(size))
+		if err != nil {
+			return err
+		}
+
+		values, err := decodeRecord(payload)
+		if err != nil {
+			return err
+		}
+
+		err = handler(rowID, values)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Right-most child page of interior pages
+	if pageType == pageInteriorTable {
+		return d.walk(int(binary.BigEndian.Uint32(header[8:12])), handler)
+	}
+
+	return nil
+}
+
+// Parse column names from CREATE TABLE statement
+// Returns the list of columns and the index of the rowid alias column
+func parseColumns(statement string) ([]string, int) {
+
+	columns := []string{}
+	rowIDAlias := -1
+
+	start := strings.Index(statement, "(")
+	end := strings.LastIndex(statement, ")")
+	if start == -1 || end <= start {
+		return columns, rowIDAlias
+	}
+
+	// Split definitions on top-level commas
+	definitions := []string{}
+	depth := 0
+	current := ""
+	for _, char := range statement[start+1 : end] {
+		switch {
+		case char == '(':
+			depth++
+		case char == ')':
+			depth--
+		case char == ',' && depth == 0:
+			definitions = append(definitions, current)
+			current = ""
+			continue
+		}
+		current += string(char)
+	}
+	definitions = append(definitions, current)
+
+	for _, definition := range definitions {
+		fields := strings.Fields(definition)
+		if len(fields) == 0 {
+			continue
+		}
+
+		// Skip table constraints
+		switch strings.ToUpper(fields[0]) {
+		case "PRIMARY", "UNIQUE", "CHECK", "FOREIGN", "CONSTRAINT":
+			continue
+		}
+
+		name := strings.Trim(fields[0], "\"`[]'")
+		upper := strings.ToUpper(strings.Join(fields[1:], " "))
+		if strings.HasPrefix(upper, "INTEGER PRIMARY KEY") {
+			rowIDAlias = len(columns)
+		}
+
+		columns = append(columns, name)
+	}
+
+	return columns, rowIDAlias
+}
+
+// Read all rows of table with given name
+func (d *Database) Table(name string) ([]Row, error) {
+
+	rows := []Row{}
+
+	// Find table root page and schema on master table
+	rootPage := 0
+	statement := ""
+	err := d.walk(1, func(rowID int64, values []any) error {
+		if len(values) < 5 {
+			return nil
+		}
+		if values[0] == "table" && values[1] == name {
+			if page, ok := values[3].(int64); ok {
+				rootPage = int(page)
+			}
+			if sql, ok := values[4].(string); ok {
+				statement = sql
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return rows, err
+	} else if rootPage == 0 {
+		return rows, fmt.Errorf("%w: %s", ErrTableNotFound, name)
+	}
+
+	// Read table records into rows
+	// Columns added later with ALTER TABLE can be missing in older records
+	columns, rowIDAlias := parseColumns(statement)
+	err = d.walk(rootPage, func(rowID int64, values []any) error {
+		row := Row{}
+		for index, column := range columns {
+			if index == rowIDAlias {
+				row[column] = rowID
+			} else if index < len(values) {
+				row[column] = values[index]
+			} else {
+				row[column] = nil
+			}
+		}
+		rows = append(rows, row)
+		return nil
+	})
+
+	return rows, err
+}
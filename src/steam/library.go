@@ -353,6 +353,37 @@ func (l *Library) Save() error {
 	return nil
 }
 
+// Select Steam user account managed by the library
+// Shortcuts of the new account are filled on the next sync
+func (l *Library) SetAccount(accountId string) error {
+
+	err := l.Load()
+	if err != nil {
+		return err
+	} else if l.BasePath == "" {
+		return fmt.Errorf("could not detect Steam installation")
+	}
+
+	configPath := filepath.Join(l.BasePath, "userdata", accountId, "config")
+	exist, err := fs.DirectoryExist(configPath)
+	if err != nil {
+		return err
+	} else if !exist || accountId == "0" {
+		return fmt.Errorf("steam account not found: %s", accountId)
+	}
+
+	// Checksums and timestamps belong to shortcuts of the previous account
+	l.AccountId = accountId
+	l.AccountName = accountId
+	l.ConfigPath = configPath
+	l.ImagesPath = filepath.Join(configPath, "grid")
+	l.ShortcutsPath = filepath.Join(configPath, "shortcuts.vdf")
+	l.Checksums = make(map[uint]string, 0)
+	l.Timestamps = make(map[uint]int64, 0)
+
+	return fs.WriteJSON(l.DatabasePath, l)
+}
+
 // Check if library is available to perform operations
 func (l *Library) Available() bool {
 	return l.BasePath != "" && l.AccountId != ""
@@ -531,6 +562,30 @@ func (l *Library) Update(reference *Internal, overwriteAssets bool) error {
 	return nil
 }
 
+// Hide or show shortcuts with given IDs in the library
+// Returns the IDs of shortcuts that changed visibility
+func (l *Library) SetHidden(IDs []string, hidden bool) []string {
+
+	changed := make([]string, 0)
+	value := uint(0)
+	if hidden {
+		value = 1
+	}
+
+	for _, ID := range IDs {
+		appID := shortcuts.ToUint(ID)
+		for _, shortcut := range l.Shortcuts {
+			if shortcut.AppID == appID && shortcut.IsHidden != value {
+				cli.Debug("Setting shortcut hidden state in Steam: %v\n", appID)
+				shortcut.IsHidden = value
+				changed = append(changed, ID)
+			}
+		}
+	}
+
+	return changed
+}
+
 // Remove shortcut from the library
 func (l *Library) Remove(reference *Internal) error {
 
@@ -0,0 +1,778 @@
+package yaml
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Parser struct
+// Parser supports the block and flow styles commonly used on configuration
+// files: mappings, sequences, quoted and plain scalars, literal and folded
+// block scalars and comments. Anchors, aliases, tags and multiple documents
+// are not supported
+type parser struct {
+	lines []string
+	index int
+}
+
+// Plain scalar keeps the original text until its type is resolved
+type plain string
+
+// Decode YAML content and put into target with JSON rules
+// Plain scalars are decoded leniently as text on string fields, like numeric IDs
+func Unmarshal(content []byte, target any) error {
+
+	value, err := parse(content)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(convert(value, reflect.TypeOf(target)))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, target)
+}
+
+// Decode YAML content into generic values
+// Mappings are decoded as map[string]any and sequences as []any
+func Decode(content []byte) (any, error) {
+
+	value, err := parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolve(value), nil
+}
+
+// Resolve plain scalars of parsed value into null, boolean, number or string
+func resolve(value any) any {
+
+	switch value := value.(type) {
+	case plain:
+		return resolveScalar(string(value))
+	case map[string]any:
+		for key, item := range value {
+			value[key] = resolve(item)
+		}
+	case []any:
+		for index, item := range value {
+			value[index] = resolve(item)
+		}
+	}
+
+	return value
+}
+
+// Resolve plain scalars of parsed value based on the target type
+// Plain scalars are kept as text when the target is a string
+func convert(value any, target reflect.Type) any {
+
+	for target != nil && target.Kind() == reflect.Pointer {
+		target = target.Elem()
+	}
+	if target == nil {
+		return resolve(value)
+	}
+
+	switch value := value.(type) {
+	case plain:
+		resolved := resolveScalar(string(value))
+		if resolved != nil && target.Kind() == reflect.String {
+			return string(value)
+		}
+		return resolved
+	case []any:
+		if target.Kind() == reflect.Slice || target.Kind() == reflect.Array {
+			for index, item := range value {
+				value[index] = convert(item, target.Elem())
+			}
+			return value
+		}
+	case map[string]any:
+		if target.Kind() == reflect.Map {
+			for key, item := range value {
+				value[key] = convert(item, target.Elem())
+			}
+			return value
+		}
+		if target.Kind() == reflect.Struct {
+			convertFields(value, target)
+			return resolve(value)
+		}
+	}
+
+	return resolve(value)
+}
+
+// Convert values of mapping based on the fields of target struct
+// Keys match field names case insensitively, like on JSON decoding
+func convertFields(value map[string]any, target reflect.Type) {
+	for index := range target.NumField() {
+		field := target.Field(index)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		// Fields of embedded struct are promoted to the parent
+		embedded := field.Type
+		for embedded.Kind() == reflect.Pointer {
+			embedded = embedded.Elem()
+		}
+		if field.Anonymous && name == "" && embedded.Kind() == reflect.Struct {
+			convertFields(value, embedded)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		for key, item := range value {
+			if strings.EqualFold(key, name) {
+				value[key] = convert(item, field.Type)
+			}
+		}
+	}
+}
+
+// Parse YAML content keeping plain scalars unresolved
+func parse(content []byte) (any, error) {
+
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+	text = strings.TrimPrefix(text, "\uFEFF")
+
+	p := &parser{lines: strings.Split(text, "\n")}
+
+	// Skip directives and document start marker
+	for p.index < len(p.lines) {
+		line := strings.TrimSpace(stripComment(p.lines[p.index]))
+		if line == "" || strings.HasPrefix(line, "%") {
+			p.index++
+			continue
+		}
+		if line == "---" {
+			p.index++
+		}
+		break
+	}
+
+	_, indent, ok := p.peek()
+	if !ok {
+		return nil, nil
+	}
+
+	value, err := p.parseBlock(indent)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only a single document is allowed
+	if line, _, ok := p.peek(); ok && line != "..." {
+		return nil, p.errorf("unexpected content: %s", line)
+	}
+
+	return value, nil
+}
+
+// Create error with the current line number
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("yaml: line %d: %s", p.index+1, fmt.Sprintf(format, args...))
+}
+
+// Retrieve next meaningful line without comments and its indentation
+func (p *parser) peek() (string, int, bool) {
+	for p.index < len(p.lines) {
+		raw := p.lines[p.index]
+		line := strings.TrimRight(stripComment(raw), " \t")
+		content := strings.TrimLeft(line, " ")
+		if content == "" {
+			p.index++
+			continue
+		}
+
+		return content, len(line) - len(content), true
+	}
+
+	return "", 0, false
+}
+
+// Remove comment from line, ignoring the content of quoted strings
+func stripComment(line string) string {
+
+	quote := byte(0)
+	for index := 0; index < len(line); index++ {
+		char := line[index]
+		switch {
+		case quote == '"' && char == '\\':
+			index++
+		case quote == '\'' && char == '\'' && index+1 < len(line) && line[index+1] == '\'':
+			index++
+		case quote != 0 && char == quote:
+			quote = 0
+		case quote == 0 && (char == '"' || char == '\'') &&
+			(index == 0 || strings.ContainsRune(" \t[{,:-", rune(line[index-1]))):
+			quote = char
+		case quote == 0 && char == '#' &&
+			(index == 0 || line[index-1] == ' ' || line[index-1] == '\t'):
+			return line[:index]
+		}
+	}
+
+	return line
+}
+
+// Check if content is an item of block sequence
+func isSequenceItem(content string) bool {
+	return content == "-" || strings.HasPrefix(content, "- ")
+}
+
+// Find separator of mapping key and value, returning -1 when not found
+func findSeparator(content string) int {
+
+	quote := byte(0)
+	depth := 0
+	for index := 0; index < len(content); index++ {
+		char := content[index]
+		switch {
+		case quote == '"' && char == '\\':
+			index++
+		case quote != 0 && char == quote:
+			quote = 0
+		case quote != 0:
+			continue
+		case (char == '"' || char == '\'') && index == 0:
+			quote = char
+		case char == '[' || char == '{':
+			depth++
+		case char == ']' || char == '}':
+			depth--
+		case char == ':' && depth == 0:
+			if index+1 == len(content) || content[index+1] == ' ' || content[index+1] == '\t' {
+				return index
+			}
+		}
+	}
+
+	return -1
+}
+
+// Parse block node at given indentation
+func (p *parser) parseBlock(indent int) (any, error) {
+
+	content, current, ok := p.peek()
+	if !ok || current < indent {
+		return nil, nil
+	}
+	if strings.Contains(p.lines[p.index][:current], "\t") {
+		return nil, p.errorf("tabs are not allowed for indentation")
+	}
+
+	if isSequenceItem(content) {
+		return p.parseSequence(current)
+	}
+	if findSeparator(content) >= 0 {
+		return p.parseMapping(current)
+	}
+
+	// Plain or quoted scalar, which can span multiple lines when plain
+	p.index++
+	return p.parseInline(p.continuation(content, current-1))
+}
+
+// Join plain scalar with continuation lines more indented than parent
+func (p *parser) continuation(content string, parent int) string {
+
+	if strings.ContainsAny(content[:1], "\"'[{") {
+		return content
+	}
+
+	for {
+		next, nextIndent, ok := p.peek()
+		if !ok || nextIndent <= parent || findSeparator(next) >= 0 {
+			break
+		}
+		content += " " + next
+		p.index++
+	}
+
+	return content
+}
+
+// Parse block sequence at given indentation
+func (p *parser) parseSequence(indent int) (any, error) {
+
+	result := []any{}
+	for {
+		content, current, ok := p.peek()
+		if !ok || current != indent || !isSequenceItem(content) {
+			break
+		}
+
+		rest := strings.TrimLeft(strings.TrimPrefix(content, "-"), " ")
+		if rest == "" {
+			p.index++
+			value, err := p.parseBlock(indent + 1)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, value)
+			continue
+		}
+
+		// Content after the dash is parsed as a node at its own column
+		column := indent + len(content) - len(rest)
+		p.lines[p.index] = strings.Repeat(" ", column) + rest
+
+		value, err := p.parseItem(column, rest)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, value)
+	}
+
+	return result, nil
+}
+
+// Parse node that starts on the line of a sequence item
+func (p *parser) parseItem(column int, content string) (any, error) {
+	if isSequenceItem(content) {
+		return p.parseSequence(column)
+	}
+	if findSeparator(content) >= 0 {
+		return p.parseMapping(column)
+	}
+
+	p.index++
+	if isBlockScalar(content) {
+		return p.parseBlockScalar(content, column-1)
+	}
+
+	return p.parseInline(p.continuation(content, column-1))
+}
+
+// Parse block mapping at given indentation
+func (p *parser) parseMapping(indent int) (any, error) {
+
+	result := map[string]any{}
+	for {
+		content, current, ok := p.peek()
+		if !ok || current != indent || isSequenceItem(content) {
+			if ok && current > indent {
+				return nil, p.errorf("unexpected indentation")
+			}
+			break
+		}
+
+		separator := findSeparator(content)
+		if separator < 0 {
+			return nil, p.errorf("expected mapping key: %s", content)
+		}
+
+		key, err := p.parseKey(strings.TrimSpace(content[:separator]))
+		if err != nil {
+			return nil, err
+		}
+		if _, exist := result[key]; exist {
+			return nil, p.errorf("duplicated key: %s", key)
+		}
+
+		rest := strings.TrimSpace(content[separator+1:])
+		p.index++
+
+		var value any
+		switch {
+		case rest == "":
+			next, nextIndent, ok := p.peek()
+			if ok && nextIndent > indent {
+				value, err = p.parseBlock(nextIndent)
+			} else if ok && nextIndent == indent && isSequenceItem(next) {
+				value, err = p.parseSequence(indent)
+			}
+		case isBlockScalar(rest):
+			value, err = p.parseBlockScalar(rest, indent)
+		default:
+			value, err = p.parseInline(p.continuation(rest, indent))
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		result[key] = value
+	}
+
+	return result, nil
+}
+
+// Parse mapping key, which can be quoted
+func (p *parser) parseKey(content string) (string, error) {
+	if strings.HasPrefix(content, "\"") || strings.HasPrefix(content, "'") {
+		value, err := p.parseInline(content)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprint(value), nil
+	}
+	if strings.HasPrefix(content, "?") || strings.HasPrefix(content, "&") ||
+		strings.HasPrefix(content, "*") || strings.HasPrefix(content, "!") {
+		return "", p.errorf("unsupported key: %s", content)
+	}
+
+	return content, nil
+}
+
+// Check if content is the header of literal or folded block scalar
+func isBlockScalar(content string) bool {
+	switch content {
+	case "|", "|-", "|+", ">", ">-", ">+":
+		return true
+	}
+
+	return false
+}
+
+// Parse literal or folded block scalar with content more indented than parent
+func (p *parser) parseBlockScalar(header string, parent int) (any, error) {
+
+	lines := []string{}
+	indent := -1
+
+	for p.index < len(p.lines) {
+		raw := p.lines[p.index]
+		content := strings.TrimLeft(raw, " ")
+		current := len(raw) - len(content)
+
+		if content == "" {
+			lines = append(lines, "")
+			p.index++
+			continue
+		}
+		if current <= parent {
+			break
+		}
+		if indent < 0 {
+			indent = current
+		} else if current < indent {
+			return nil, p.errorf("invalid indentation of block scalar")
+		}
+
+		lines = append(lines, raw[indent:])
+		p.index++
+	}
+
+	// Trailing empty lines are handled by chomping indicator
+	trailing := 0
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+		trailing++
+	}
+
+	text := ""
+	if strings.HasPrefix(header, ">") {
+		for index, line := range lines {
+			switch {
+			case index == 0:
+				text = line
+			case line == "" || lines[index-1] == "" ||
+				strings.HasPrefix(line, " ") || strings.HasPrefix(lines[index-1], " "):
+				text += "\n" + line
+			default:
+				text += " " + line
+			}
+		}
+	} else {
+		text = strings.Join(lines, "\n")
+	}
+
+	switch {
+	case len(lines) == 0:
+		return "", nil
+	case strings.HasSuffix(header, "-"):
+		return text, nil
+	case strings.HasSuffix(header, "+"):
+		return text + "\n" + strings.Repeat("\n", trailing), nil
+	}
+
+	return text + "\n", nil
+}
+
+// Parse value written on a single line, like scalars and flow collections
+func (p *parser) parseInline(content string) (any, error) {
+
+	flow := &flowParser{content: content}
+	value, err := flow.parseValue()
+	if err != nil {
+		return nil, p.errorf("%s", err)
+	}
+
+	flow.skipSpaces()
+	if flow.position < len(flow.content) {
+		return nil, p.errorf("unexpected content: %s", flow.content[flow.position:])
+	}
+
+	return value, nil
+}
+
+// Flow parser struct
+type flowParser struct {
+	content  string
+	position int
+	depth    int
+}
+
+// Skip spaces on flow content
+func (f *flowParser) skipSpaces() {
+	for f.position < len(f.content) && (f.content[f.position] == ' ' || f.content[f.position] == '\t') {
+		f.position++
+	}
+}
+
+// Parse next value on flow content
+func (f *flowParser) parseValue() (any, error) {
+
+	f.skipSpaces()
+	if f.position >= len(f.content) {
+		return nil, nil
+	}
+
+	switch f.content[f.position] {
+	case '[':
+		return f.parseSequence()
+	case '{':
+		return f.parseMapping()
+	case '"':
+		return f.parseDoubleQuoted()
+	case '\'':
+		return f.parseSingleQuoted()
+	case '&', '*', '!':
+		return nil, fmt.Errorf("anchors, aliases and tags are not supported")
+	}
+
+	// Plain scalar ends at the end of content or flow indicators
+	start := f.position
+	for f.position < len(f.content) {
+		char := f.content[f.position]
+		if f.depth > 0 && (char == ',' || char == ']' || char == '}') {
+			break
+		}
+		if f.depth > 0 && char == ':' &&
+			(f.position+1 == len(f.content) || strings.ContainsRune(" ,]}", rune(f.content[f.position+1]))) {
+			break
+		}
+		f.position++
+	}
+
+	return plain(strings.TrimSpace(f.content[start:f.position])), nil
+}
+
+// Parse flow sequence
+func (f *flowParser) parseSequence() (any, error) {
+
+	result := []any{}
+	f.position++
+	f.depth++
+
+	for {
+		f.skipSpaces()
+		if f.position >= len(f.content) {
+			return nil, fmt.Errorf("unterminated flow sequence")
+		}
+		if f.content[f.position] == ']' {
+			f.position++
+			f.depth--
+			return result, nil
+		}
+
+		value, err := f.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+
+		f.skipSpaces()
+		if f.position < len(f.content) && f.content[f.position] == ',' {
+			f.position++
+		}
+	}
+}
+
+// Parse flow mapping
+func (f *flowParser) parseMapping() (any, error) {
+
+	result := map[string]any{}
+	f.position++
+	f.depth++
+
+	for {
+		f.skipSpaces()
+		if f.position >= len(f.content) {
+			return nil, fmt.Errorf("unterminated flow mapping")
+		}
+		if f.content[f.position] == '}' {
+			f.position++
+			f.depth--
+			return result, nil
+		}
+
+		key, err := f.parseValue()
+		if err != nil {
+			return nil, err
+		}
+
+		f.skipSpaces()
+		var value any
+		if f.position < len(f.content) && f.content[f.position] == ':' {
+			f.position++
+			value, err = f.parseValue()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		key = resolve(key)
+		if key == nil {
+			key = ""
+		}
+		result[fmt.Sprint(key)] = value
+
+		f.skipSpaces()
+		if f.position < len(f.content) && f.content[f.position] == ',' {
+			f.position++
+		}
+	}
+}
+
+// Parse double quoted scalar with escape sequences
+func (f *flowParser) parseDoubleQuoted() (any, error) {
+
+	var builder strings.Builder
+	f.position++
+
+	for f.position < len(f.content) {
+		char := f.content[f.position]
+		if char == '"' {
+			f.position++
+			return builder.String(), nil
+		}
+		if char != '\\' {
+			builder.WriteByte(char)
+			f.position++
+			continue
+		}
+
+		if f.position+1 >= len(f.content) {
+			break
+		}
+
+		escape := f.content[f.position+1]
+		f.position += 2
+
+		switch escape {
+		case '0':
+			builder.WriteByte(0)
+		case 'a':
+			builder.WriteByte('\a')
+		case 'b':
+			builder.WriteByte('\b')
+		case 't', '\t':
+			builder.WriteByte('\t')
+		case 'n':
+			builder.WriteByte('\n')
+		case 'v':
+			builder.WriteByte('\v')
+		case 'f':
+			builder.WriteByte('\f')
+		case 'r':
+			builder.WriteByte('\r')
+		case 'e':
+			builder.WriteByte(0x1b)
+		case ' ', '"', '/', '\\':
+			builder.WriteByte(escape)
+		case 'x', 'u', 'U':
+			size := map[byte]int{'x': 2, 'u': 4, 'U': 8}[escape]
+			if f.position+size > len(f.content) {
+				return nil, fmt.Errorf("invalid escape sequence")
+			}
+			code, err := strconv.ParseUint(f.content[f.position:f.position+size], 16, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid escape sequence")
+			}
+			builder.WriteRune(rune(code))
+			f.position += size
+		default:
+			return nil, fmt.Errorf("invalid escape sequence: \\%c", escape)
+		}
+	}
+
+	return nil, fmt.Errorf("unterminated double quoted string")
+}
+
+// Parse single quoted scalar, where two quotes represent one quote
+func (f *flowParser) parseSingleQuoted() (any, error) {
+
+	var builder strings.Builder
+	f.position++
+
+	for f.position < len(f.content) {
+		char := f.content[f.position]
+		if char == '\'' {
+			if f.position+1 < len(f.content) && f.content[f.position+1] == '\'' {
+				builder.WriteByte('\'')
+				f.position += 2
+				continue
+			}
+			f.position++
+			return builder.String(), nil
+		}
+
+		builder.WriteByte(char)
+		f.position++
+	}
+
+	return nil, fmt.Errorf("unterminated single quoted string")
+}
+
+// Resolve plain scalar into null, boolean, number or string
+func resolveScalar(value string) any {
+
+	switch value {
+	case "", "~", "null", "Null", "NULL":
+		return nil
+	case "true", "True", "TRUE":
+		return true
+	case "false", "False", "FALSE":
+		return false
+	}
+
+	if strings.HasPrefix(value, "0x") {
+		if number, err := strconv.ParseInt(value[2:], 16, 64); err == nil {
+			return number
+		}
+	}
+	if strings.HasPrefix(value, "0o") {
+		if number, err := strconv.ParseInt(value[2:], 8, 64); err == nil {
+			return number
+		}
+	}
+	if number, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return number
+	}
+
+	// Special float values are kept as strings, since JSON can not represent them
+	numeric := strings.Trim(value, "0123456789.eE+-") == ""
+	if number, err := strconv.ParseFloat(value, 64); err == nil && numeric {
+		return number
+	}
+
+	return value
+}
@@ -0,0 +1,336 @@
+package yaml
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Profile shapes used on tests, matching the profile file format
+type testPlatform struct {
+	Name    string `json:"name"`
+	Console string `json:"console"`
+	Folder  string `json:"folder"`
+}
+
+type testEmulator struct {
+	Name          string `json:"name"`
+	Platform      string `json:"platform"`
+	Program       string `json:"program"`
+	Core          string `json:"core"`
+	Extensions    string `json:"extensions"`
+	LaunchOptions string `json:"launchOptions"`
+}
+
+type testRoot struct {
+	ID        string   `json:"id"`
+	Label     string   `json:"label"`
+	Path      string   `json:"path"`
+	Platforms []string `json:"platforms"`
+}
+
+type testSource struct {
+	Linux   []string `json:"linux"`
+	MacOS   []string `json:"macos"`
+	Windows []string `json:"windows"`
+}
+
+type testState struct {
+	Platform    string      `json:"platform"`
+	Emulator    string      `json:"emulator"`
+	Type        string      `json:"type"`
+	Sources     *testSource `json:"sources"`
+	Destination string      `json:"destination"`
+	Include     []string    `json:"include"`
+	Exclude     []string    `json:"exclude"`
+}
+
+type testSteam struct {
+	Account string `json:"account"`
+}
+
+type testScraper struct {
+	APIKey string   `json:"apiKey"`
+	Images []string `json:"images"`
+}
+
+type testProfile struct {
+	Programs    []string       `json:"programs"`
+	Preferences []string       `json:"preferences"`
+	Platforms   []testPlatform `json:"platforms"`
+	Emulators   []testEmulator `json:"emulators"`
+	Roots       []testRoot     `json:"roots"`
+	States      []testState    `json:"states"`
+	Steam       *testSteam     `json:"steam,omitempty"`
+	Scraper     *testScraper   `json:"scraper"`
+}
+
+func TestUnmarshalProfile(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		content string
+		want    testProfile
+	}{{
+		name:    "empty document",
+		content: "# Nothing here\n",
+		want:    testProfile{},
+	}, {
+		name: "block and flow sequences",
+		content: `---
+programs:
+  - retroarch
+  - duckstation # Comment after item
+preferences: [use-steam-runtime, skip-configure]
+`,
+		want: testProfile{
+			Programs:    []string{"retroarch", "duckstation"},
+			Preferences: []string{"use-steam-runtime", "skip-configure"},
+		},
+	}, {
+		name: "sequence items without indentation",
+		content: `programs:
+- pcsx2
+- "ppsspp"
+- 'cemu'
+`,
+		want: testProfile{
+			Programs: []string{"pcsx2", "ppsspp", "cemu"},
+		},
+	}, {
+		name: "numeric Steam account",
+		content: `steam:
+  account: 12345678
+`,
+		want: testProfile{
+			Steam: &testSteam{Account: "12345678"},
+		},
+	}, {
+		name:    "numeric Steam account in flow mapping",
+		content: "steam: {account: 0012345678}\n",
+		want: testProfile{
+			Steam: &testSteam{Account: "0012345678"},
+		},
+	}, {
+		name: "quoted Steam account",
+		content: `steam:
+  account: "12345678"
+`,
+		want: testProfile{
+			Steam: &testSteam{Account: "12345678"},
+		},
+	}, {
+		name: "custom platforms and emulators",
+		content: `platforms:
+  - name: PICO8
+    console: PICO-8
+    folder: PICO8
+emulators:
+  - name: Pico
+    platform: PICO8
+    program: pico8
+    core: ""
+    extensions: .p8 .png
+    launchOptions: -run ${ROM}
+  - name: RetroArch
+    platform: PICO8
+    program: retroarch
+    core: fake08
+    extensions: ".p8"
+    launchOptions: '-f -L ${CORE} ${ROM}'
+`,
+		want: testProfile{
+			Platforms: []testPlatform{{Name: "PICO8", Console: "PICO-8", Folder: "PICO8"}},
+			Emulators: []testEmulator{{
+				Name:          "Pico",
+				Platform:      "PICO8",
+				Program:       "pico8",
+				Extensions:    ".p8 .png",
+				LaunchOptions: "-run ${ROM}",
+			}, {
+				Name:          "RetroArch",
+				Platform:      "PICO8",
+				Program:       "retroarch",
+				Core:          "fake08",
+				Extensions:    ".p8",
+				LaunchOptions: "-f -L ${CORE} ${ROM}",
+			}},
+		},
+	}, {
+		name: "roots with numeric and boolean like values",
+		content: `roots:
+  - id: 2024
+    label: yes
+    path: /run/media/deck/SD Card/Games
+    platforms: [PS2, 3DS, true]
+`,
+		want: testProfile{
+			Roots: []testRoot{{
+				ID:        "2024",
+				Label:     "yes",
+				Path:      "/run/media/deck/SD Card/Games",
+				Platforms: []string{"PS2", "3DS", "true"},
+			}},
+		},
+	}, {
+		name: "states with nested sources",
+		content: `states:
+  - platform: PS2
+    emulator: PCSX2
+    type: folder
+    sources:
+      linux:
+        - $VAR/net.pcsx2.PCSX2/config/PCSX2/memcards
+      macos: []
+      windows: ['$DOCUMENTS\PCSX2\memcards']
+    destination: $STATE/PCSX2/memcards
+    include:
+      - "*.ps2"
+    exclude: [cache/, "*.log"]
+`,
+		want: testProfile{
+			States: []testState{{
+				Platform: "PS2",
+				Emulator: "PCSX2",
+				Type:     "folder",
+				Sources: &testSource{
+					Linux:   []string{"$VAR/net.pcsx2.PCSX2/config/PCSX2/memcards"},
+					MacOS:   []string{},
+					Windows: []string{`$DOCUMENTS\PCSX2\memcards`},
+				},
+				Destination: "$STATE/PCSX2/memcards",
+				Include:     []string{"*.ps2"},
+				Exclude:     []string{"cache/", "*.log"},
+			}},
+		},
+	}, {
+		name: "scraper with block scalar and null",
+		content: `scraper:
+  apiKey: >-
+    abc
+    def
+  images: ~
+`,
+		want: testProfile{
+			Scraper: &testScraper{APIKey: "abc def"},
+		},
+	}, {
+		name: "multi-line plain scalar",
+		content: `platforms:
+  - name: NAOMI
+    console: Sega
+      NAOMI Arcade
+    folder: NAOMI
+`,
+		want: testProfile{
+			Platforms: []testPlatform{{Name: "NAOMI", Console: "Sega NAOMI Arcade", Folder: "NAOMI"}},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := testProfile{}
+			err := Unmarshal([]byte(test.content), &result)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(result, test.want) {
+				t.Fatalf("unexpected result:\n got: %#v\nwant: %#v", result, test.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "tab indentation", content: "programs:\n\t- retroarch\n"},
+		{name: "duplicated key", content: "programs: []\nprograms: []\n"},
+		{name: "unterminated flow", content: "programs: [retroarch\n"},
+		{name: "unterminated quote", content: "steam:\n  account: \"123\n"},
+		{name: "anchors", content: "programs: &list [retroarch]\n"},
+		{name: "multiple documents", content: "programs: []\n---\nprograms: []\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := testProfile{}
+			err := Unmarshal([]byte(test.content), &result)
+			if err == nil {
+				t.Fatalf("expected error, got: %#v", result)
+			}
+		})
+	}
+}
+
+func TestDecodeScalars(t *testing.T) {
+
+	tests := []struct {
+		content string
+		want    any
+	}{
+		{content: "value: 42", want: int64(42)},
+		{content: "value: 0x1F", want: int64(31)},
+		{content: "value: 1.5", want: 1.5},
+		{content: "value: true", want: true},
+		{content: "value: null", want: nil},
+		{content: "value: .inf", want: ".inf"},
+		{content: "value: '42'", want: "42"},
+		{content: "value: it's fine", want: "it's fine"},
+		{content: "value: 'it''s quoted' # comment", want: "it's quoted"},
+		{content: "value: \"tab\\tand \\u00e9\"", want: "tab\tand é"},
+		{content: "value: a #b", want: "a"},
+		{content: "value: a#b", want: "a#b"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.content, func(t *testing.T) {
+			result, err := Decode([]byte(test.content))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			mapping, ok := result.(map[string]any)
+			if !ok {
+				t.Fatalf("unexpected result: %#v", result)
+			}
+			if !reflect.DeepEqual(mapping["value"], test.want) {
+				t.Fatalf("unexpected value: got %#v, want %#v", mapping["value"], test.want)
+			}
+		})
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+
+	profile := testProfile{
+		Programs:    []string{"retroarch", "pcsx2"},
+		Preferences: []string{},
+		Roots: []testRoot{{
+			ID:        "sd",
+			Label:     "SD Card: Games",
+			Path:      "/run/media/deck/SD Card",
+			Platforms: []string{"PS2"},
+		}},
+		Steam: &testSteam{Account: "12345678"},
+		Scraper: &testScraper{
+			APIKey: "- not a list #",
+			Images: []string{"true", "null", "multi\nline"},
+		},
+	}
+
+	content, err := Marshal(profile)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	result := testProfile{}
+	err = Unmarshal(content, &result)
+	if err != nil {
+		t.Fatalf("unexpected error: %s\n%s", err, content)
+	}
+	if !reflect.DeepEqual(result, profile) {
+		t.Fatalf("unexpected result:\n got: %#v\nwant: %#v\n%s", result, profile, content)
+	}
+}
@@ -0,0 +1,188 @@
+package yaml
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Item of ordered mapping
+type item struct {
+	Key   string
+	Value any
+}
+
+// Encode source into YAML content with JSON rules
+// Fields are written in the same order of the JSON representation
+func Marshal(source any) ([]byte, error) {
+
+	data, err := json.Marshal(source)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	value, err := readOrdered(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	if isBlock(value) {
+		writeNode(&buffer, value, "", false)
+	} else {
+		buffer.WriteString(encodeScalar(value) + "\n")
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// Read next JSON value keeping the order of mapping keys
+func readOrdered(decoder *json.Decoder) (any, error) {
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delimiter, ok := token.(json.Delim)
+	if !ok {
+		return token, nil
+	}
+
+	switch delimiter {
+	case '{':
+		result := []item{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := readOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, item{Key: key.(string), Value: value})
+		}
+		_, err := decoder.Token()
+		return result, err
+	case '[':
+		result := []any{}
+		for decoder.More() {
+			value, err := readOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, value)
+		}
+		_, err := decoder.Token()
+		return result, err
+	}
+
+	return nil, io.ErrUnexpectedEOF
+}
+
+// Check if value is an empty mapping or sequence
+func isEmpty(value any) bool {
+	switch value := value.(type) {
+	case []item:
+		return len(value) == 0
+	case []any:
+		return len(value) == 0
+	}
+
+	return false
+}
+
+// Check if value is written as nested block
+func isBlock(value any) bool {
+	switch value.(type) {
+	case []item, []any:
+		return !isEmpty(value)
+	}
+
+	return false
+}
+
+// Write mapping or sequence as block with given indentation
+// When inline, first line continues the current line, like on sequence items
+func writeNode(buffer *bytes.Buffer, value any, indent string, inline bool) {
+
+	switch value := value.(type) {
+	case []item:
+		for index, entry := range value {
+			if index > 0 || !inline {
+				buffer.WriteString(indent)
+			}
+			buffer.WriteString(encodeString(entry.Key) + ":")
+			if isBlock(entry.Value) {
+				buffer.WriteString("\n")
+				writeNode(buffer, entry.Value, indent+"  ", false)
+			} else {
+				buffer.WriteString(" " + encodeScalar(entry.Value) + "\n")
+			}
+		}
+	case []any:
+		for _, entry := range value {
+			buffer.WriteString(indent + "-")
+			switch entry.(type) {
+			case []item:
+				if isBlock(entry) {
+					buffer.WriteString(" ")
+					writeNode(buffer, entry, indent+"  ", true)
+					continue
+				}
+			case []any:
+				if isBlock(entry) {
+					buffer.WriteString("\n")
+					writeNode(buffer, entry, indent+"  ", false)
+					continue
+				}
+			}
+			buffer.WriteString(" " + encodeScalar(entry) + "\n")
+		}
+	}
+}
+
+// Encode value written on a single line
+func encodeScalar(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(value)
+	case json.Number:
+		return value.String()
+	case string:
+		return encodeString(value)
+	case []item:
+		return "{}"
+	case []any:
+		return "[]"
+	}
+
+	return ""
+}
+
+// Encode string, quoting it when plain style would change its meaning
+func encodeString(value string) string {
+
+	plain := value != "" &&
+		resolveScalar(value) == value &&
+		strings.TrimSpace(value) == value &&
+		!strings.ContainsAny(value[:1], "-?:,[]{}#&*!|>'\"%@`") &&
+		!strings.Contains(value, ": ") &&
+		!strings.Contains(value, " #") &&
+		!strings.HasSuffix(value, ":") &&
+		strconv.Quote(value) == "\""+value+"\""
+
+	if plain {
+		return value
+	}
+
+	return strconv.Quote(value)
+}